@@ -14,10 +14,13 @@ var listCmd = &cobra.Command{
 	Long:  `列出指定分类下的所有命令，如果不指定分类则列出所有命令`,
 	Example: `  cmd4coder list
   cmd4coder list "操作系统/Ubuntu系统命令"
-  cmd4coder list "编程语言/Java工具链"`,
+  cmd4coder list "编程语言/Java工具链"
+  cmd4coder list --output json | jq '.commands[].name'`,
+	Annotations: structuredOutput(),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		var commands []*model.Command
 		var title string
+		result := &commandList{Platform: hostPlatform()}
 
 		if len(args) == 0 {
 			// 列出所有命令
@@ -28,35 +31,42 @@ var listCmd = &cobra.Command{
 			category := args[0]
 			commands = cmdService.ListCommandsByCategory(category)
 			title = fmt.Sprintf("分类: %s", category)
+			result.Category = category
 		}
+		commands = cmdService.ForHost(commands)
+		title += platformSuffix()
 
-		if len(commands) == 0 {
-			fmt.Println("未找到命令")
-			return nil
-		}
-
-		// 输出命令列表
-		fmt.Printf("\n%s (共 %d 个命令)\n", title, len(commands))
-		fmt.Println(strings.Repeat("=", 80))
+		result.Total = len(commands)
+		result.Commands = summarize(commands)
 
-		for _, cmd := range commands {
-			riskIndicator := getRiskIndicator(cmd.GetHighestRisk())
-			installIndicator := ""
-			if cmd.InstallRequired {
-				installIndicator = "[需安装]"
+		return render(result, func() {
+			if len(commands) == 0 {
+				fmt.Println("未找到命令")
+				printAllPlatformsHint()
+				return
 			}
 
-			fmt.Printf("%-20s %s %s %s\n",
-				cmd.Name,
-				riskIndicator,
-				installIndicator,
-				cmd.Description)
-		}
-
-		fmt.Println()
-		fmt.Println("使用 'cmd4coder show <命令名>' 查看详细信息")
+			// 输出命令列表
+			fmt.Printf("\n%s (共 %d 个命令)\n", title, len(commands))
+			fmt.Println(strings.Repeat("=", 80))
+
+			for _, cmd := range commands {
+				riskIndicator := getRiskIndicator(cmd.GetHighestRisk())
+				installIndicator := ""
+				if cmd.InstallRequired {
+					installIndicator = "[需安装]"
+				}
+
+				fmt.Printf("%-20s %s %s %s\n",
+					cmd.Name,
+					riskIndicator,
+					installIndicator,
+					cmd.Description)
+			}
 
-		return nil
+			fmt.Println()
+			fmt.Println("使用 'cmd4coder show <命令名>' 查看详细信息")
+		})
 	},
 }
 
@@ -66,8 +76,10 @@ var showCmd = &cobra.Command{
 	Long:  `显示指定命令的完整信息，包括用法、选项、示例、注意事项和风险说明`,
 	Example: `  cmd4coder show ls
   cmd4coder show docker
-  cmd4coder show git`,
-	Args: cobra.ExactArgs(1),
+  cmd4coder show git
+  cmd4coder show tar --output yaml`,
+	Args:        cobra.ExactArgs(1),
+	Annotations: structuredOutput(),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cmdName := args[0]
 		command, err := cmdService.GetCommand(cmdName)
@@ -75,7 +87,29 @@ var showCmd = &cobra.Command{
 			return fmt.Errorf("命令 '%s' 未找到", cmdName)
 		}
 
+		// 记录查看历史
+		if cfgService != nil {
+			cfgService.AddHistory(command.Name, command.Category)
+		}
+
+		if structured() {
+			// 结构化输出按当前平台选用对应的写法
+			if host := cmdService.Host(); host != nil {
+				command = command.ForPlatform(host.OS)
+			}
+			return render((*commandDetail)(command), nil)
+		}
+
 		printCommandDetail(command)
+
+		// 个人片段
+		if cfgService != nil {
+			if snippets := cfgService.SnippetsFor(command.Name); len(snippets) > 0 {
+				fmt.Printf("📌 我的片段:\n")
+				printSnippets(snippets, false)
+				fmt.Println()
+			}
+		}
 		return nil
 	},
 }
@@ -86,79 +120,140 @@ var searchCmd = &cobra.Command{
 	Long:  `根据关键词搜索命令，支持模糊匹配和多关键词`,
 	Example: `  cmd4coder search file
   cmd4coder search network
-  cmd4coder search "java 诊断"`,
-	Args: cobra.MinimumNArgs(1),
+  cmd4coder search "java 诊断"
+  cmd4coder search nginx --output json | jq -r '.commands[].name'`,
+	Args:        cobra.MinimumNArgs(1),
+	Annotations: structuredOutput(),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		query := strings.Join(args, " ")
-		commands := cmdService.SearchCommands(query)
+		commands := cmdService.ForHost(cmdService.SearchCommands(query))
 
-		if len(commands) == 0 {
-			fmt.Printf("未找到与 '%s' 相关的命令\n", query)
-			return nil
+		var snippets []model.Snippet
+		if cfgService != nil {
+			snippets = cfgService.SearchSnippets(query)
 		}
 
-		fmt.Printf("\n搜索结果: '%s' (共 %d 个命令)\n", query, len(commands))
-		fmt.Println(strings.Repeat("=", 80))
-
-		for _, command := range commands {
-			riskIndicator := getRiskIndicator(command.GetHighestRisk())
-			fmt.Printf("%-20s %s %s\n",
-				command.Name,
-				riskIndicator,
-				command.Description)
+		result := &searchResult{
+			Query:    query,
+			Platform: hostPlatform(),
+			Total:    len(commands),
+			Commands: summarize(commands),
+		}
+		for _, snippet := range snippets {
+			result.Snippets = append(result.Snippets, snippetSummary{
+				Name:        snippet.Name,
+				CommandName: snippet.CommandName,
+				Command:     snippet.Command,
+				Description: snippet.Description,
+			})
 		}
 
-		fmt.Println()
-		fmt.Println("使用 'cmd4coder show <命令名>' 查看详细信息")
+		return render(result, func() {
+			if len(commands) == 0 && len(snippets) == 0 {
+				fmt.Printf("未找到与 '%s' 相关的命令\n", query)
+				printAllPlatformsHint()
+				return
+			}
 
-		return nil
+			fmt.Printf("\n搜索结果: '%s'%s (共 %d 个命令)\n", query, platformSuffix(), len(commands))
+			fmt.Println(strings.Repeat("=", 80))
+
+			for _, command := range commands {
+				riskIndicator := getRiskIndicator(command.GetHighestRisk())
+				fmt.Printf("%-20s %s %s\n",
+					command.Name,
+					riskIndicator,
+					command.Description)
+			}
+
+			if len(snippets) > 0 {
+				fmt.Printf("\n📌 我的片段 (共 %d 个)\n", len(snippets))
+				printSnippets(snippets, true)
+			}
+
+			fmt.Println()
+			fmt.Println("使用 'cmd4coder show <命令名>' 查看详细信息")
+		})
 	},
 }
 
 var categoriesCmd = &cobra.Command{
-	Use:   "categories",
-	Short: "列出所有分类",
-	Long:  `显示所有可用的命令分类`,
+	Use:         "categories",
+	Short:       "列出所有分类",
+	Long:        `显示所有可用的命令分类`,
+	Annotations: structuredOutput(),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		categories := cmdService.GetAllCategories()
 
-		fmt.Printf("\n所有分类 (共 %d 个)\n", len(categories))
-		fmt.Println(strings.Repeat("=", 80))
-
+		result := &categoryList{Total: len(categories), Categories: make([]categorySummary, 0, len(categories))}
 		for _, category := range categories {
 			commands := cmdService.ListCommandsByCategory(category)
-			fmt.Printf("%-40s (%d 个命令)\n", category, len(commands))
+			result.Categories = append(result.Categories, categorySummary{Name: category, Count: len(commands)})
 		}
 
-		fmt.Println()
-		fmt.Println("使用 'cmd4coder list <分类名>' 查看分类下的命令")
+		return render(result, func() {
+			fmt.Printf("\n所有分类 (共 %d 个)\n", len(categories))
+			fmt.Println(strings.Repeat("=", 80))
 
-		return nil
+			for _, c := range result.Categories {
+				fmt.Printf("%-40s (%d 个命令)\n", c.Name, c.Count)
+			}
+
+			fmt.Println()
+			fmt.Println("使用 'cmd4coder list <分类名>' 查看分类下的命令")
+		})
 	},
 }
 
 var versionCmd = &cobra.Command{
-	Use:   "version",
-	Short: "显示版本信息",
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Printf("cmd4coder version %s\n", Version)
-		fmt.Printf("Build time: %s\n", BuildTime)
-		fmt.Printf("Commit: %s\n", CommitHash)
-
+	Use:         "version",
+	Short:       "显示版本信息",
+	Annotations: structuredOutput(),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		info := &versionInfo{Version: Version, BuildTime: BuildTime, Commit: CommitHash}
 		if cmdService != nil {
-			metadata := cmdService.GetMetadata()
-			if metadata != nil {
-				fmt.Printf("Data version: %s\n", metadata.Version)
-				fmt.Printf("Data updated: %s\n", metadata.UpdatedAt)
+			if metadata := cmdService.GetMetadata(); metadata != nil {
+				info.DataVersion = metadata.Version
+				info.DataUpdated = metadata.UpdatedAt
 			}
-			fmt.Printf("Total commands: %d\n", cmdService.GetCommandCount())
-			fmt.Printf("Total categories: %d\n", cmdService.GetCategoryCount())
+			info.Commands = cmdService.GetCommandCount()
+			info.Categories = cmdService.GetCategoryCount()
 		}
+
+		return render(info, func() {
+			fmt.Printf("cmd4coder version %s\n", Version)
+			fmt.Printf("Build time: %s\n", BuildTime)
+			fmt.Printf("Commit: %s\n", CommitHash)
+
+			if cmdService != nil {
+				if info.DataVersion != "" || info.DataUpdated != "" {
+					fmt.Printf("Data version: %s\n", info.DataVersion)
+					fmt.Printf("Data updated: %s\n", info.DataUpdated)
+				}
+				fmt.Printf("Total commands: %d\n", info.Commands)
+				fmt.Printf("Total categories: %d\n", info.Categories)
+			}
+		})
 	},
 }
 
 // Helper functions
 
+// platformSuffix 按平台过滤时在标题后注明当前平台
+func platformSuffix() string {
+	if host := cmdService.Host(); host != nil {
+		return fmt.Sprintf(" [%s]", host)
+	}
+	return ""
+}
+
+// printAllPlatformsHint 按平台过滤且没有结果时提示查看所有平台
+func printAllPlatformsHint() {
+	if cmdService.Host() != nil {
+		fmt.Println("使用 --all-platforms 查看所有平台的命令")
+	}
+}
+
 func getRiskIndicator(risk model.RiskLevel) string {
 	switch risk {
 	case model.RiskLevelLow:
@@ -175,23 +270,94 @@ func getRiskIndicator(risk model.RiskLevel) string {
 }
 
 func printCommandDetail(cmd *model.Command) {
+	// 按当前平台选用对应的用法、选项和示例
+	current := ""
+	if host := cmdService.Host(); host != nil {
+		current = host.OS
+		cmd = cmd.ForPlatform(current)
+	}
+
 	fmt.Printf("\n命令: %s\n", cmd.Name)
 	fmt.Println(strings.Repeat("=", 80))
 
 	fmt.Printf("\n📝 描述:\n  %s\n", cmd.Description)
 	fmt.Printf("\n📂 分类: %s\n", cmd.Category)
 	fmt.Printf("💻 平台: %s\n", strings.Join(cmd.Platforms, ", "))
+	if len(cmd.Distros) > 0 {
+		fmt.Printf("🐧 发行版: %s\n", strings.Join(cmd.Distros, ", "))
+	}
+	if host := cmdService.Host(); host != nil && !cmdService.SupportsHost(cmd) {
+		fmt.Printf("⚠️  此命令不适用于当前平台 (%s)\n", host)
+	}
+
+	// 其他系统上的等价命令
+	if equivalents := cmdService.Equivalents(cmd); len(equivalents) > 0 {
+		fmt.Printf("🔁 等价命令 (%s):\n", cmd.Intent())
+		for _, eq := range equivalents {
+			systems := eq.Distros
+			if len(systems) == 0 {
+				systems = eq.Platforms
+			}
+			fmt.Printf("  %-20s %s\n", eq.Name, strings.Join(systems, ", "))
+		}
+	}
+
+	// 更安全的替代做法（高风险命令优先提示）
+	if len(cmd.SaferAlternatives) > 0 {
+		fmt.Printf("\n🛡️  更安全的替代做法:\n")
+		for _, alt := range cmd.SaferAlternatives {
+			fmt.Printf("  • %s\n", alt.Description)
+			if alt.Flags != "" {
+				fmt.Printf("    选项: %s\n", alt.Flags)
+			}
+			if alt.Example != "" {
+				fmt.Printf("    $ %s\n", alt.Example)
+			}
+			if alt.Command != "" {
+				fmt.Printf("    参见: cmd4coder show \"%s\"\n", alt.Command)
+			}
+		}
+	}
+
+	// 预演方式
+	if cmd.DryRun != nil {
+		fmt.Printf("\n🧪 预演选项: %s\n", cmd.DryRun.Flag)
+		if cmd.DryRun.Note != "" {
+			fmt.Printf("  %s\n", cmd.DryRun.Note)
+		}
+		fmt.Printf("  生成预演命令: cmd4coder preview <命令行>\n")
+	}
 
 	if cmd.InstallRequired {
 		fmt.Printf("\n📦 安装方式:\n  %s\n", cmd.InstallMethod)
 	}
 
 	// 使用方式
-	fmt.Printf("\n💡 使用方式:\n")
+	if _, ok := cmd.PlatformOverrides[current]; ok {
+		fmt.Printf("\n💡 使用方式 (%s):\n", current)
+	} else {
+		fmt.Printf("\n💡 使用方式:\n")
+	}
 	for _, usage := range cmd.Usage {
 		fmt.Printf("  %s\n", usage)
 	}
 
+	// 其他平台的不同写法
+	var others []string
+	for _, platform := range cmd.OverridePlatforms() {
+		if platform != current && len(cmd.PlatformOverrides[platform].Usage) > 0 {
+			others = append(others, platform)
+		}
+	}
+	if len(others) > 0 {
+		fmt.Printf("\n🔀 其他平台写法:\n")
+		for _, platform := range others {
+			for _, usage := range cmd.PlatformOverrides[platform].Usage {
+				fmt.Printf("  [%s] %s\n", platform, usage)
+			}
+		}
+	}
+
 	// 常用选项
 	if len(cmd.Options) > 0 {
 		fmt.Printf("\n⚙️  常用选项:\n")
@@ -232,6 +398,7 @@ func printCommandDetail(cmd *model.Command) {
 	// 相关命令
 	if len(cmd.RelatedCommands) > 0 {
 		fmt.Printf("\n🔗 相关命令: %s\n", strings.Join(cmd.RelatedCommands, ", "))
+		fmt.Printf("  查看关系图: cmd4coder graph \"%s\"\n", cmd.Name)
 	}
 
 	// 参考链接
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cmd4coder/cmd4coder/internal/model"
+	"github.com/cmd4coder/cmd4coder/internal/service"
+	"github.com/cmd4coder/cmd4coder/pkg/export"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportQuery      string
+	exportCategories []string
+	exportRisk       string
+	exportFormat     string
+	exportCompact    bool
+	exportOutput     string
+	exportTitle      string
+	exportGroup      string
+	exportNoRisks    bool
+	exportDir        string
+	exportPages      int
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export [command...]",
+	Short: "导出命令为文档、速查表、man 手册或补全脚本",
+	Long: `导出指定的命令，或按关键词、分类、风险级别筛选出的命令（默认按本机平台过滤，可配合 --platform、--all-platforms）。
+
+未指定格式和输出文件时使用配置中的 export.default_format、export.output_dir 和 export.include_date，
+-o - 输出到标准输出；--dir 把 html 导出为多页面静态站点（含离线搜索），把 markdown 按分类拆分为多个文件（README.md 为总目录），
+把 man 导出为按程序划分的手册页。
+zsh-completion、fish-completion 只为还没有补全的程序生效，不会覆盖已有的补全。`,
+	Example: `  cmd4coder export ls -f markdown -o ls.md
+  cmd4coder export --category "容器编排/Docker命令" -o docker.md
+  cmd4coder export --risk high -f json -o - | jq '.total'
+  cmd4coder export --all-platforms -f json -o commands.json
+  cmd4coder export --all-platforms -f html --dir docs/commands
+  cmd4coder export --all-platforms -f markdown --dir docs/reference
+  cmd4coder export --all-platforms -f man --dir ~/.local/share/man
+  cmd4coder export --all-platforms -f zsh-completion -o ~/.cmd4coder-completion.zsh
+  cmd4coder export -c "版本控制/Git命令" -f anki -o git-deck.tsv
+  cmd4coder export -c "容器编排/Docker命令" -c "容器编排/Kubernetes命令" -f cheatsheet --pages 2 -o oncall.html`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) > 0 && (exportQuery != "" || len(exportCategories) > 0) {
+			return fmt.Errorf("指定命令名称时不能同时使用 --query 或 --category")
+		}
+
+		filter := service.CommandFilter{
+			Names:      args,
+			Query:      exportQuery,
+			Categories: exportCategories,
+		}
+		if exportRisk != "" {
+			filter.MinRisk = model.RiskLevel(exportRisk)
+			if !filter.MinRisk.IsValid() {
+				return fmt.Errorf("--risk 必须是 low、medium、high 或 critical")
+			}
+		}
+
+		commands, err := cmdService.FilterCommands(filter)
+		if err != nil {
+			var notFound model.ErrCommandNotFound
+			if errors.As(err, &notFound) {
+				return fmt.Errorf("命令 '%s' 未找到", notFound.Name)
+			}
+			return err
+		}
+		if len(commands) == 0 {
+			printAllPlatformsHint()
+			return fmt.Errorf("没有符合条件的命令")
+		}
+
+		cfg := exportConfig()
+		format := exportFormat
+		if format == "" {
+			format = cfg.DefaultFormat
+		}
+
+		if exportCompact && format == "json" {
+			format = "json-compact"
+		}
+		exporter, err := export.Get(format)
+		if err != nil {
+			return fmt.Errorf("不支持的导出格式: %s，可选: %s", format, strings.Join(export.Formats(), ", "))
+		}
+
+		opts := export.DefaultOptions()
+		opts.Title = exportTitle
+		opts.IncludeRisks = !exportNoRisks
+		if exportPages < 0 {
+			return fmt.Errorf("--pages 不能为负数")
+		}
+		opts.PageBudget = exportPages
+		if cfgService != nil {
+			opts.Locale = cfgService.GetConfig().Language
+		}
+		switch export.Grouping(exportGroup) {
+		case export.GroupByCategory, export.GroupNone:
+			opts.GroupBy = export.Grouping(exportGroup)
+		default:
+			return fmt.Errorf("--group 必须是 category 或 none")
+		}
+
+		if exportDir != "" {
+			dirExporter, ok := exporter.(export.DirExporter)
+			if !ok {
+				return fmt.Errorf("格式 %s 不支持导出到目录", format)
+			}
+			if err := dirExporter.ExportDir(exportDir, commands, opts); err != nil {
+				return err
+			}
+			fmt.Printf("✅ 已导出 %d 个命令到目录 %s\n", len(commands), exportDir)
+			return nil
+		}
+
+		// 速查表可能按页数上限省略命令，报告实际列出的数量
+		listed, omitted, pages := len(commands), 0, 0
+		if paged, ok := exporter.(export.PagedExporter); ok {
+			listed, omitted, pages = paged.Layout(commands, opts)
+		}
+
+		if exportOutput == "-" {
+			if err := exporter.Export(os.Stdout, commands, opts); err != nil {
+				return err
+			}
+			if omitted > 0 {
+				fmt.Fprintf(os.Stderr, "⚠️  %d 个命令超出 --pages %d 的页数上限，未列出\n", omitted, exportPages)
+			}
+			return nil
+		}
+
+		filename := exportOutput
+		if filename == "" {
+			filename = cfg.OutputPath("commands", exporter.Extension(), time.Now())
+		}
+		if err := export.ExportToFile(exporter, commands, filename, opts); err != nil {
+			return err
+		}
+
+		if pages > 0 {
+			fmt.Printf("✅ 已导出 %d 个命令到 %s（预计 %d 页）\n", listed, filename, pages)
+		} else {
+			fmt.Printf("✅ 已导出 %d 个命令到 %s\n", listed, filename)
+		}
+		if omitted > 0 {
+			fmt.Printf("⚠️  %d 个命令超出 --pages %d 的页数上限，未列出\n", omitted, exportPages)
+		}
+		return nil
+	},
+}
+
+func init() {
+	exportCmd.Flags().StringVarP(&exportQuery, "query", "q", "", "按关键词筛选")
+	exportCmd.Flags().StringSliceVarP(&exportCategories, "category", "c", nil, "按分类筛选（可重复）")
+	exportCmd.Flags().StringVar(&exportRisk, "risk", "", "只导出不低于该风险级别的命令: low, medium, high, critical")
+	exportCmd.Flags().StringVarP(&exportFormat, "format", "f", "", "导出格式: "+strings.Join(export.Formats(), ", ")+"（默认取配置 export.default_format）")
+	exportCmd.Flags().BoolVar(&exportCompact, "compact", false, "JSON 不缩进，只输出命令数组（同 -f json-compact）")
+	exportCmd.Flags().StringVar(&exportTitle, "title", "", "文档标题")
+	exportCmd.Flags().StringVar(&exportGroup, "group", string(export.GroupByCategory), "分组方式: category, none")
+	exportCmd.Flags().BoolVar(&exportNoRisks, "no-risks", false, "不包含风险说明")
+	exportCmd.Flags().IntVar(&exportPages, "pages", 0, "速查表页数上限，超出时先减少示例再截断命令（仅 cheatsheet 格式，0 表示不限）")
+	exportCmd.Flags().StringVar(&exportDir, "dir", "", "导出到目录：html 生成多页面站点，markdown 每个分类一个文件，man 生成 man7/ 下的手册页")
+	exportCmd.Flags().StringVarP(&exportOutput, "output-file", "o", "", "输出文件，- 表示标准输出（默认按配置生成）")
+}
+
+// exportConfig 导出配置，无法加载用户配置时使用默认值
+func exportConfig() model.ExportConfig {
+	if cfgService != nil {
+		if cfg := cfgService.GetConfig(); cfg != nil {
+			return cfg.Export
+		}
+	}
+	return model.DefaultConfig().Export
+}
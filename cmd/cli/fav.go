@@ -0,0 +1,246 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cmd4coder/cmd4coder/internal/model"
+	"github.com/cmd4coder/cmd4coder/pkg/export"
+	"github.com/spf13/cobra"
+)
+
+var (
+	favTags        []string
+	favCollections []string
+	favNote        string
+	favTag         string
+	favCollection  string
+	favFormat      string
+	favOutputFile  string
+)
+
+var favCmd = &cobra.Command{
+	Use:               "fav",
+	Short:             "管理收藏",
+	Long:              `管理收藏的命令，支持标签、备注和收藏集（如 oncall、db-migration）`,
+	PersistentPreRunE: requireUserData,
+}
+
+var favAddCmd = &cobra.Command{
+	Use:   "add <command>",
+	Short: "添加收藏",
+	Long:  `收藏命令，已收藏的命令会追加标签和收藏集`,
+	Example: `  cmd4coder fav add "kubectl logs" --tag k8s --tag debug
+  cmd4coder fav add psql --collection db-migration --note "先连只读副本"`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		command, err := cmdService.GetCommand(args[0])
+		if err != nil {
+			return fmt.Errorf("命令 '%s' 未找到", args[0])
+		}
+
+		existing := cfgService.FindFavorite(command.Name)
+		if existing == nil {
+			if err := cfgService.AddFavorite(command.Name, command.Category, favNote); err != nil {
+				return err
+			}
+		} else if cmd.Flags().Changed("note") {
+			if err := cfgService.SetFavoriteNote(command.Name, favNote); err != nil {
+				return err
+			}
+		}
+
+		if len(favTags) > 0 || len(favCollections) > 0 {
+			if err := cfgService.TagFavorite(command.Name, favTags, favCollections); err != nil {
+				return err
+			}
+		}
+
+		if existing == nil {
+			fmt.Printf("⭐ 已收藏: %s\n", command.Name)
+		} else {
+			fmt.Printf("⭐ 已更新收藏: %s\n", command.Name)
+		}
+		return nil
+	},
+}
+
+var favRmCmd = &cobra.Command{
+	Use:   "rm <command>",
+	Short: "删除收藏",
+	Long:  `删除收藏；指定 --tag 或 --collection 时只移除对应的标签或收藏集`,
+	Example: `  cmd4coder fav rm "kubectl logs"
+  cmd4coder fav rm psql --collection db-migration`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		if cfgService.FindFavorite(name) == nil {
+			return fmt.Errorf("命令未收藏: %s", name)
+		}
+
+		if len(favTags) > 0 || len(favCollections) > 0 {
+			if err := cfgService.UntagFavorite(name, favTags, favCollections); err != nil {
+				return err
+			}
+			fmt.Printf("✅ 已从 %s 移除标签/收藏集\n", name)
+			return nil
+		}
+
+		if err := cfgService.RemoveFavorite(name); err != nil {
+			return err
+		}
+		fmt.Printf("✅ 已取消收藏: %s\n", name)
+		return nil
+	},
+}
+
+var favLsCmd = &cobra.Command{
+	Use:   "ls",
+	Short: "列出收藏",
+	Long:  `列出收藏的命令，可按标签或收藏集筛选`,
+	Example: `  cmd4coder fav ls
+  cmd4coder fav ls --tag debug
+  cmd4coder fav ls --collection oncall`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		favorites := cfgService.FilterFavorites(favTag, favCollection)
+		if len(favorites) == 0 {
+			fmt.Println("没有符合条件的收藏")
+			return nil
+		}
+
+		fmt.Printf("\n收藏 (共 %d 个命令)\n", len(favorites))
+		fmt.Println(strings.Repeat("=", 80))
+
+		for _, fav := range favorites {
+			fmt.Printf("%-20s %s\n", fav.CommandName, formatFavoriteLabels(fav))
+			if fav.Note != "" {
+				fmt.Printf("  📝 %s\n", fav.Note)
+			}
+		}
+
+		if tags := cfgService.FavoriteTags(); len(tags) > 0 {
+			fmt.Printf("\n标签: %s\n", strings.Join(tags, ", "))
+		}
+		if collections := cfgService.FavoriteCollections(); len(collections) > 0 {
+			fmt.Printf("收藏集: %s\n", strings.Join(collections, ", "))
+		}
+
+		return nil
+	},
+}
+
+var favNoteCmd = &cobra.Command{
+	Use:   "note <command> [note...]",
+	Short: "修改收藏备注",
+	Long:  `修改收藏的备注，不提供备注内容时清空备注`,
+	Example: `  cmd4coder fav note psql "生产库只读账号: readonly"
+  cmd4coder fav note psql`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		note := strings.Join(args[1:], " ")
+		if err := cfgService.SetFavoriteNote(args[0], note); err != nil {
+			return err
+		}
+
+		if note == "" {
+			fmt.Printf("✅ 已清空备注: %s\n", args[0])
+		} else {
+			fmt.Printf("✅ 已更新备注: %s\n", args[0])
+		}
+		return nil
+	},
+}
+
+var favExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "导出收藏的命令",
+	Long:  `把收藏的命令（可按标签或收藏集筛选）导出为 Markdown 或 JSON，收藏备注会附加到命令注意事项中`,
+	Example: `  cmd4coder fav export --collection oncall -o oncall.md
+  cmd4coder fav export --tag k8s --format json -o k8s.json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		favorites := cfgService.FilterFavorites(favTag, favCollection)
+		if len(favorites) == 0 {
+			return fmt.Errorf("没有符合条件的收藏")
+		}
+
+		var commands []*model.Command
+		for _, fav := range favorites {
+			command, err := cmdService.GetCommand(fav.CommandName)
+			if err != nil {
+				fmt.Printf("⚠️  跳过已不存在的命令: %s\n", fav.CommandName)
+				continue
+			}
+			if fav.Note != "" {
+				// 复制一份，避免修改索引中的命令
+				copied := *command
+				copied.Notes = append([]string{"收藏备注: " + fav.Note}, command.Notes...)
+				command = &copied
+			}
+			commands = append(commands, command)
+		}
+
+		exporter, err := export.Get(favFormat)
+		if err != nil {
+			return fmt.Errorf("不支持的导出格式: %s", favFormat)
+		}
+
+		filename := favOutputFile
+		if filename == "" {
+			filename = "favorites." + exporter.Extension()
+		}
+		if err := export.ExportToFile(exporter, commands, filename, export.DefaultOptions()); err != nil {
+			return err
+		}
+
+		fmt.Printf("✅ 已导出 %d 个收藏的命令到 %s\n", len(commands), filename)
+		return nil
+	},
+}
+
+// requireUserData 在根命令初始化之后检查用户数据是否可用，供管理用户数据的命令组使用
+func requireUserData(cmd *cobra.Command, args []string) error {
+	if err := rootCmd.PersistentPreRunE(cmd, args); err != nil {
+		return err
+	}
+	if cfgService == nil {
+		return fmt.Errorf("无法加载用户数据")
+	}
+	return nil
+}
+
+// formatFavoriteLabels 格式化收藏的标签和收藏集
+func formatFavoriteLabels(fav model.Favorite) string {
+	var labels []string
+	for _, tag := range fav.Tags {
+		labels = append(labels, "#"+tag)
+	}
+	for _, collection := range fav.Collections {
+		labels = append(labels, "@"+collection)
+	}
+	return strings.Join(labels, " ")
+}
+
+func init() {
+	favAddCmd.Flags().StringSliceVarP(&favTags, "tag", "t", nil, "标签（可重复）")
+	favAddCmd.Flags().StringSliceVar(&favCollections, "collection", nil, "收藏集（可重复）")
+	favAddCmd.Flags().StringVar(&favNote, "note", "", "备注")
+
+	favRmCmd.Flags().StringSliceVarP(&favTags, "tag", "t", nil, "只移除这些标签")
+	favRmCmd.Flags().StringSliceVar(&favCollections, "collection", nil, "只移除这些收藏集")
+
+	favLsCmd.Flags().StringVarP(&favTag, "tag", "t", "", "按标签筛选")
+	favLsCmd.Flags().StringVar(&favCollection, "collection", "", "按收藏集筛选")
+
+	favExportCmd.Flags().StringVarP(&favTag, "tag", "t", "", "按标签筛选")
+	favExportCmd.Flags().StringVar(&favCollection, "collection", "", "按收藏集筛选")
+	favExportCmd.Flags().StringVarP(&favFormat, "format", "f", "markdown", "导出格式: "+strings.Join(export.Formats(), ", "))
+	favExportCmd.Flags().StringVarP(&favOutputFile, "output-file", "o", "", "输出文件（默认 favorites.md 或 favorites.json）")
+
+	favCmd.AddCommand(favAddCmd)
+	favCmd.AddCommand(favRmCmd)
+	favCmd.AddCommand(favLsCmd)
+	favCmd.AddCommand(favNoteCmd)
+	favCmd.AddCommand(favExportCmd)
+}
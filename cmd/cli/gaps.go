@@ -0,0 +1,132 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cmd4coder/cmd4coder/pkg/history"
+	"github.com/spf13/cobra"
+)
+
+var (
+	gapsHistoryFiles []string
+	gapsFormat       string
+	gapsTop          int
+)
+
+var gapsCmd = &cobra.Command{
+	Use:   "gaps",
+	Short: "根据shell历史分析数据缺口",
+	Long: `解析 shell 历史（bash、zsh 扩展历史、fish），把调用对应到已收录命令，
+报告常用但未收录的工具和子命令，以及实际使用到的选项，指导补充数据。
+
+不指定 --history 时依次读取 ~/.bash_history、~/.zsh_history 和 fish 历史。`,
+	Example: `  cmd4coder gaps
+  cmd4coder gaps --history ~/.bash_history
+  cmd4coder gaps --history ~/.zsh_history --format zsh --top 50`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format := history.Format(gapsFormat)
+		if !format.IsValid() {
+			return fmt.Errorf("不支持的历史格式: %s", gapsFormat)
+		}
+
+		files := gapsHistoryFiles
+		if len(files) == 0 {
+			files = defaultHistoryFiles()
+		}
+		if len(files) == 0 {
+			return fmt.Errorf("未找到 shell 历史文件，请使用 --history 指定")
+		}
+
+		var invocations []history.Invocation
+		for _, file := range files {
+			lines, err := history.ReadFile(file, format)
+			if err != nil {
+				return err
+			}
+			for _, line := range lines {
+				invocations = append(invocations, history.Parse(line)...)
+			}
+		}
+
+		report := cmdService.AnalyzeHistory(invocations)
+
+		fmt.Printf("\n历史覆盖分析 (%s)\n", strings.Join(files, ", "))
+		fmt.Println(strings.Repeat("=", 80))
+		coverage := 0.0
+		if report.TotalInvocations > 0 {
+			coverage = float64(report.MatchedInvocations) / float64(report.TotalInvocations) * 100
+		}
+		fmt.Printf("调用总数: %d  已收录: %d (%.1f%%)\n",
+			report.TotalInvocations, report.MatchedInvocations, coverage)
+
+		if len(report.MissingTools) > 0 {
+			fmt.Printf("\n❌ 未收录的常用工具:\n")
+			for i, u := range report.MissingTools {
+				if i >= gapsTop {
+					break
+				}
+				fmt.Printf("  %-30s %5d 次\n", u.Name, u.Count)
+			}
+		}
+
+		if len(report.MissingSubcommands) > 0 {
+			fmt.Printf("\n⚠️  已收录工具中未收录的子命令:\n")
+			for i, u := range report.MissingSubcommands {
+				if i >= gapsTop {
+					break
+				}
+				fmt.Printf("  %-30s %5d 次\n", u.Name, u.Count)
+			}
+		}
+
+		if len(report.OptionUsage) > 0 {
+			fmt.Printf("\n⚙️  已收录命令的选项使用:\n")
+			for i, u := range report.OptionUsage {
+				if i >= gapsTop {
+					break
+				}
+				fmt.Printf("\n  %s (%d 次)\n", u.Command, u.Count)
+				for _, opt := range u.Documented {
+					fmt.Printf("    ✓ %-26s %5d 次\n", opt.Name, opt.Count)
+				}
+				for _, opt := range u.Undocumented {
+					fmt.Printf("    + %-26s %5d 次 (未收录)\n", opt.Name, opt.Count)
+				}
+			}
+		}
+
+		fmt.Println()
+		return nil
+	},
+}
+
+func init() {
+	gapsCmd.Flags().StringSliceVar(&gapsHistoryFiles, "history", nil, "shell 历史文件路径（可多次指定）")
+	gapsCmd.Flags().StringVar(&gapsFormat, "format", string(history.FormatAuto), "历史格式: auto|bash|zsh|fish")
+	gapsCmd.Flags().IntVar(&gapsTop, "top", 20, "每项最多显示的条目数")
+}
+
+// defaultHistoryFiles 返回存在的默认 shell 历史文件
+func defaultHistoryFiles() []string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil
+	}
+
+	candidates := []string{
+		filepath.Join(homeDir, ".bash_history"),
+		filepath.Join(homeDir, ".zsh_history"),
+		filepath.Join(homeDir, ".local", "share", "fish", "fish_history"),
+	}
+
+	var files []string
+	for _, path := range candidates {
+		if _, err := os.Stat(path); err == nil {
+			files = append(files, path)
+		}
+	}
+	return files
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/cmd4coder/cmd4coder/internal/model"
+	"github.com/spf13/cobra"
+)
+
+var (
+	graphDepth  int
+	graphFormat string
+	graphOutput string
+)
+
+var graphCmd = &cobra.Command{
+	Use:   "graph <command>",
+	Short: "输出命令关系图",
+	Long: `以指定命令为中心，沿相关命令、等价命令和更安全的替代命令展开关系图，
+输出 Graphviz DOT 或 Mermaid 格式，可直接渲染或粘贴到文档中`,
+	Example: `  cmd4coder graph "git clone"
+  cmd4coder graph "apt install" --depth 2 --format mermaid
+  cmd4coder graph dropdb -o dropdb.dot && dot -Tsvg dropdb.dot > dropdb.svg`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var write func(*model.CommandGraph, io.Writer) error
+		switch graphFormat {
+		case "dot":
+			write = (*model.CommandGraph).WriteDOT
+		case "mermaid":
+			write = (*model.CommandGraph).WriteMermaid
+		default:
+			return fmt.Errorf("不支持的格式 '%s'，可选: dot, mermaid", graphFormat)
+		}
+
+		graph, err := cmdService.Neighbors(args[0], graphDepth)
+		if err != nil {
+			return fmt.Errorf("命令 '%s' 未找到", args[0])
+		}
+
+		out := io.Writer(os.Stdout)
+		if graphOutput != "" {
+			f, err := os.Create(graphOutput)
+			if err != nil {
+				return fmt.Errorf("failed to create file: %w", err)
+			}
+			defer f.Close()
+			out = f
+		}
+
+		if err := write(graph, out); err != nil {
+			return err
+		}
+		if graphOutput != "" {
+			fmt.Fprintf(os.Stderr, "✅ 关系图已写入 %s（%d 个命令，%d 条关系）\n", graphOutput, len(graph.Nodes), len(graph.Edges))
+		}
+		return nil
+	},
+}
+
+func init() {
+	graphCmd.Flags().IntVar(&graphDepth, "depth", 1, "展开的层数")
+	graphCmd.Flags().StringVar(&graphFormat, "format", "dot", "输出格式: dot, mermaid")
+	graphCmd.Flags().StringVarP(&graphOutput, "output-file", "o", "", "输出文件（默认标准输出）")
+}
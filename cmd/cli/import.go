@@ -0,0 +1,310 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/cmd4coder/cmd4coder/internal/model"
+	"github.com/cmd4coder/cmd4coder/pkg/importer"
+	"github.com/spf13/cobra"
+)
+
+var (
+	importCategory  string
+	importPlatforms []string
+	importName      string
+	importOutput    string
+
+	importLang  string
+	importOnly  []string
+	importInto  string
+	importWrite bool
+
+	importIntoDir   string
+	importOverwrite bool
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "从外部文档导入命令",
+	Long:  `从 man 手册页、--help 输出、tldr-pages、导出的 JSON 等外部资料生成命令草稿或合并到现有数据`,
+}
+
+var importManCmd = &cobra.Command{
+	Use:   "man <file>",
+	Short: "从man手册页源码导入",
+	Long:  `解析本地 man 手册页源码（groff/mandoc），提取概要、选项生成命令草稿`,
+	Example: `  cmd4coder import man /usr/share/man/man1/ls.1
+  zcat /usr/share/man/man1/tar.1.gz | cmd4coder import man - --category "操作系统/通用Linux命令"`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runImport(args[0], func(r io.Reader) (*model.Command, error) {
+			return importer.ParseManPage(r)
+		})
+	},
+}
+
+var importHelpCmd = &cobra.Command{
+	Use:   "help <file>",
+	Short: "从--help输出导入",
+	Long:  `解析保存下来的 --help 输出文本，提取 Usage 和选项生成命令草稿`,
+	Example: `  ls --help | cmd4coder import help -
+  cmd4coder import help kubectl-port-forward.txt --name "kubectl port-forward"`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runImport(args[0], func(r io.Reader) (*model.Command, error) {
+			return importer.ParseHelpText(importName, r)
+		})
+	},
+}
+
+var importTLDRCmd = &cobra.Command{
+	Use:   "tldr <path>",
+	Short: "从tldr-pages导入示例",
+	Long: `读取本地 tldr-pages 检出目录，按命令名合并到现有数据：
+只补充空字段和新的示例/链接，不覆盖人工维护的内容，并报告冲突：
+同一说明的示例对应不同命令行（不追加该示例）、tldr 页面的平台超出已有平台。
+
+默认只输出合并报告，加 --write 才会写回数据文件。`,
+	Example: `  cmd4coder import tldr ~/src/tldr --only tar,rsync
+  cmd4coder import tldr ~/src/tldr --lang zh --write
+  cmd4coder import tldr ~/src/tldr --into os/tldr.yaml --category "操作系统/通用Linux命令" --write`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		pages, err := importer.LoadTLDRPages(args[0], importLang)
+		if err != nil {
+			return err
+		}
+		if len(importOnly) > 0 {
+			pages = filterByName(pages, importOnly)
+		}
+
+		dataset, err := importer.LoadDataset(dataDir)
+		if err != nil {
+			return err
+		}
+
+		var place func(*model.Command) *importer.DataFile
+		if importInto != "" {
+			if importCategory == "" {
+				return fmt.Errorf("--into 需要同时指定 --category")
+			}
+			target := dataset.File(importInto, importCategory, "从 tldr-pages 导入的命令")
+			place = func(*model.Command) *importer.DataFile { return target }
+		}
+
+		result, err := dataset.Merge(pages, place)
+		if err != nil {
+			return err
+		}
+
+		printMergeResult(result)
+		return saveDataset(dataset)
+	},
+}
+
+var importJSONCmd = &cobra.Command{
+	Use:   "json <file>",
+	Short: "从导出的JSON导入",
+	Long: `读取 cmd4coder export -f json 或 -f json-compact 生成的文件，按分类写回 YAML 数据文件，
+用于在其他工具中编辑导出内容后写回，或与其他团队交换命令包。
+
+已有的同名命令默认只补充空字段和新的选项/示例（同 import tldr），加 --overwrite 用导入内容覆盖；
+新命令写入对应分类的数据文件，分类没有数据文件时在 --into-dir 下创建并登记到 metadata.yaml。
+
+默认只输出合并报告，加 --write 才会写回数据文件。`,
+	Example: `  cmd4coder export -c "容器编排/Docker命令" -f json -o docker.json
+  cmd4coder import json docker.json --overwrite --write
+  cmd4coder import json team-pack.json --into-dir team --write`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		in, closeIn, err := openInput(args[0])
+		if err != nil {
+			return err
+		}
+		defer closeIn()
+
+		commands, err := importer.ParseJSONExport(in)
+		if err != nil {
+			return err
+		}
+		if len(importOnly) > 0 {
+			commands = filterByName(commands, importOnly)
+		}
+
+		dataset, err := importer.LoadDataset(dataDir)
+		if err != nil {
+			return err
+		}
+
+		place := dataset.PlaceByCategory(importIntoDir, "从 JSON 导入的命令")
+		var result *importer.MergeResult
+		if importOverwrite {
+			result, err = dataset.Replace(commands, place)
+		} else {
+			result, err = dataset.Merge(commands, place)
+		}
+		if err != nil {
+			return err
+		}
+
+		printMergeResult(result)
+		return saveDataset(dataset)
+	},
+}
+
+func init() {
+	for _, c := range []*cobra.Command{importManCmd, importHelpCmd} {
+		c.Flags().StringVarP(&importCategory, "category", "c", "", "命令所属分类")
+		c.Flags().StringSliceVarP(&importPlatforms, "platform", "p", []string{"linux"}, "支持的平台")
+		c.Flags().StringVarP(&importOutput, "output-file", "o", "", "输出文件（默认标准输出）")
+		importCmd.AddCommand(c)
+	}
+	importHelpCmd.Flags().StringVarP(&importName, "name", "n", "", "命令名称（默认从Usage推断）")
+
+	importTLDRCmd.Flags().StringVar(&importLang, "lang", "", "页面语言，如 zh（默认英文）")
+	importTLDRCmd.Flags().StringSliceVar(&importOnly, "only", nil, "只导入指定命令")
+	importTLDRCmd.Flags().StringVar(&importInto, "into", "", "新命令写入的数据文件（相对数据目录），不指定则只报告")
+	importTLDRCmd.Flags().StringVarP(&importCategory, "category", "c", "", "新命令所属分类")
+	importTLDRCmd.Flags().BoolVarP(&importWrite, "write", "w", false, "写回数据文件")
+	importCmd.AddCommand(importTLDRCmd)
+
+	importJSONCmd.Flags().StringSliceVar(&importOnly, "only", nil, "只导入指定命令")
+	importJSONCmd.Flags().StringVar(&importIntoDir, "into-dir", "imported", "分类没有数据文件时新建文件的目录（相对数据目录）")
+	importJSONCmd.Flags().BoolVar(&importOverwrite, "overwrite", false, "用导入内容覆盖已有命令中的字段")
+	importJSONCmd.Flags().BoolVarP(&importWrite, "write", "w", false, "写回数据文件")
+	importCmd.AddCommand(importJSONCmd)
+}
+
+// runImport 读取输入、解析并输出命令草稿
+func runImport(path string, parse func(io.Reader) (*model.Command, error)) error {
+	in, closeIn, err := openInput(path)
+	if err != nil {
+		return err
+	}
+	defer closeIn()
+
+	command, err := parse(in)
+	if err != nil {
+		return err
+	}
+
+	if importCategory != "" {
+		command.Category = importCategory
+	}
+	if len(command.Platforms) == 0 {
+		command.Platforms = importPlatforms
+	}
+
+	if _, err := cmdService.GetCommand(command.Name); err == nil {
+		fmt.Fprintf(os.Stderr, "⚠️  命令 '%s' 已存在于数据集中\n", command.Name)
+	}
+
+	draft := importer.NewDraft(command)
+
+	out := io.Writer(os.Stdout)
+	if importOutput != "" {
+		f, err := os.Create(importOutput)
+		if err != nil {
+			return fmt.Errorf("failed to create file: %w", err)
+		}
+		defer f.Close()
+		out = f
+	}
+
+	if err := draft.WriteYAML(out); err != nil {
+		return err
+	}
+
+	if draft.NeedsReview() {
+		fmt.Fprintf(os.Stderr, "\n⚠️  草稿需要人工补充: %s\n", strings.Join(draft.Missing, ", "))
+		if draft.ValidationErr != nil {
+			fmt.Fprintf(os.Stderr, "   校验未通过: %v\n", draft.ValidationErr)
+		}
+	}
+
+	return nil
+}
+
+// openInput 打开输入文件，"-" 表示标准输入
+func openInput(path string) (io.Reader, func(), error) {
+	if path == "-" {
+		return os.Stdin, func() {}, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	return f, func() { f.Close() }, nil
+}
+
+// filterByName 只保留指定名称的命令
+func filterByName(commands []*model.Command, names []string) []*model.Command {
+	wanted := make(map[string]bool, len(names))
+	for _, name := range names {
+		wanted[name] = true
+	}
+
+	var filtered []*model.Command
+	for _, c := range commands {
+		if wanted[c.Name] {
+			filtered = append(filtered, c)
+		}
+	}
+	return filtered
+}
+
+// printMergeResult 输出合并报告
+func printMergeResult(result *importer.MergeResult) {
+	fmt.Printf("\n合并报告\n")
+	fmt.Println(strings.Repeat("=", 80))
+	fmt.Printf("新增: %d  补充: %d  未匹配: %d  冲突: %d\n",
+		len(result.Added), len(result.Updated), len(result.Unmatched), len(result.Conflicts))
+
+	if len(result.Added) > 0 {
+		fmt.Printf("\n➕ 新增命令:\n")
+		for _, c := range result.Added {
+			fmt.Printf("  %s\n", c.Name)
+		}
+	}
+
+	if len(result.Updated) > 0 {
+		fmt.Printf("\n✏️  补充内容:\n")
+		for _, c := range result.Updated {
+			fmt.Printf("  %s\n", c.Name)
+		}
+	}
+
+	if len(result.Conflicts) > 0 {
+		fmt.Printf("\n⚠️  冲突（已保留原值）:\n")
+		for _, c := range result.Conflicts {
+			fmt.Printf("  %s [%s]\n    现有: %s\n    导入: %s\n", c.Command, c.Field, c.Existing, c.Incoming)
+		}
+	}
+
+	if len(result.Unmatched) > 0 {
+		fmt.Printf("\n%d 个命令在数据集中不存在，使用 --into 指定写入的数据文件\n", len(result.Unmatched))
+	}
+}
+
+// saveDataset 按 --write 写回数据集，否则提示为预览
+func saveDataset(dataset *importer.Dataset) error {
+	if !importWrite {
+		fmt.Println("\n预览模式，未修改数据文件（使用 --write 写回）")
+		return nil
+	}
+
+	saved, err := dataset.Save()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("\n已写回 %d 个文件:\n", len(saved))
+	for _, path := range saved {
+		fmt.Printf("  %s\n", path)
+	}
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/cmd4coder/cmd4coder/internal/service"
 	"github.com/cmd4coder/cmd4coder/internal/ui/tui"
+	"github.com/cmd4coder/cmd4coder/pkg/platform"
 	"github.com/spf13/cobra"
 )
 
@@ -25,6 +26,14 @@ var (
 
 	// 数据目录
 	dataDir string
+
+	// 关闭个人化排序
+	noPersonalize bool
+
+	// 显示所有平台的命令
+	allPlatforms bool
+	// 指定平台，如 macos、linux/centos，为空时自动识别
+	platformSpec string
 )
 
 func main() {
@@ -55,6 +64,10 @@ var rootCmd = &cobra.Command{
 
 更多信息请访问: https://github.com/cmd4coder/cmd4coder`,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := checkOutputFormat(cmd); err != nil {
+			return err
+		}
+
 		// 初始化命令服务
 		if dataDir == "" {
 			// 默认数据目录
@@ -71,9 +84,25 @@ var rootCmd = &cobra.Command{
 			return fmt.Errorf("failed to initialize command service: %w", err)
 		}
 
+		// 默认只显示适用于本机平台的命令
+		if !allPlatforms {
+			host := platform.Detect()
+			if platformSpec != "" {
+				if host, err = platform.Parse(platformSpec); err != nil {
+					return fmt.Errorf("无效的平台 '%s'，可用: linux、linux/<发行版>、darwin(macos)、windows", platformSpec)
+				}
+			}
+			cmdService.SetHost(&host)
+		}
+
 		// 初始化配置服务
 		cfgService, _ = service.NewConfigService()
 
+		// 根据使用记录和收藏个人化搜索排序
+		if cfgService != nil && !noPersonalize {
+			cmdService.SetPersonalizer(cfgService.PersonalScores)
+		}
+
 		return nil
 	},
 	Run: func(cmd *cobra.Command, args []string) {
@@ -87,9 +116,23 @@ var rootCmd = &cobra.Command{
 
 func init() {
 	rootCmd.PersistentFlags().StringVarP(&dataDir, "data-dir", "d", "", "数据目录路径")
+	rootCmd.PersistentFlags().BoolVar(&noPersonalize, "no-personalize", false, "不根据个人使用记录调整排序")
+	rootCmd.PersistentFlags().BoolVar(&allPlatforms, "all-platforms", false, "显示所有平台的命令，不按本机平台过滤")
+	rootCmd.PersistentFlags().StringVar(&outputFormat, "output", outputText, "输出格式: text, json, yaml, tsv, markdown")
+	rootCmd.PersistentFlags().StringVar(&platformSpec, "platform", "", "按指定平台过滤，如 macos、linux/centos（默认自动识别本机）")
 	rootCmd.AddCommand(listCmd)
 	rootCmd.AddCommand(showCmd)
 	rootCmd.AddCommand(searchCmd)
 	rootCmd.AddCommand(categoriesCmd)
 	rootCmd.AddCommand(versionCmd)
+	rootCmd.AddCommand(importCmd)
+	rootCmd.AddCommand(gapsCmd)
+	rootCmd.AddCommand(statsCmd)
+	rootCmd.AddCommand(favCmd)
+	rootCmd.AddCommand(snippetCmd)
+	rootCmd.AddCommand(runbookCmd)
+	rootCmd.AddCommand(previewCmd)
+	rootCmd.AddCommand(translateCmd)
+	rootCmd.AddCommand(graphCmd)
+	rootCmd.AddCommand(exportCmd)
 }
@@ -0,0 +1,295 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/cmd4coder/cmd4coder/internal/model"
+	"github.com/cmd4coder/cmd4coder/pkg/export"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// 输出格式
+const (
+	outputText     = "text"
+	outputJSON     = "json"
+	outputYAML     = "yaml"
+	outputTSV      = "tsv"
+	outputMarkdown = "markdown"
+)
+
+// annotationOutput 标记支持 --output 结构化输出的子命令
+const annotationOutput = "cmd4coder/output"
+
+// outputFormat 全局 --output 选项
+var outputFormat = outputText
+
+// structuredOutput 支持结构化输出的子命令的 Annotations
+func structuredOutput() map[string]string {
+	return map[string]string{annotationOutput: "true"}
+}
+
+// checkOutputFormat 校验 --output 取值，以及子命令是否支持结构化输出
+func checkOutputFormat(cmd *cobra.Command) error {
+	switch outputFormat {
+	case outputText:
+		return nil
+	case outputJSON, outputYAML, outputTSV, outputMarkdown:
+		if cmd.Annotations[annotationOutput] == "" {
+			return fmt.Errorf("'%s' 不支持 --output %s", cmd.CommandPath(), outputFormat)
+		}
+		return nil
+	default:
+		return fmt.Errorf("不支持的输出格式 '%s'，可选: text, json, yaml, tsv, markdown", outputFormat)
+	}
+}
+
+// structured 当前是否为结构化输出，此时提示信息不应写到标准输出
+func structured() bool {
+	return outputFormat != outputText
+}
+
+// tabular 可以按表格输出（TSV、Markdown）的结果
+type tabular interface {
+	Header() []string
+	Rows() [][]string
+}
+
+// markdownWriter 自定义 Markdown 输出的结果，未实现时按表格输出
+type markdownWriter interface {
+	WriteMarkdown(w io.Writer) error
+}
+
+// render 按 --output 输出结果；text 格式调用 printText 输出原有的文本
+func render(result tabular, printText func()) error {
+	w := os.Stdout
+	switch outputFormat {
+	case outputJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
+	case outputYAML:
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(result); err != nil {
+			return fmt.Errorf("failed to encode YAML: %w", err)
+		}
+		return encoder.Close()
+	case outputTSV:
+		return writeTSV(w, result)
+	case outputMarkdown:
+		if mw, ok := result.(markdownWriter); ok {
+			return mw.WriteMarkdown(w)
+		}
+		return writeMarkdownTable(w, result)
+	default:
+		printText()
+		return nil
+	}
+}
+
+// writeTSV 以制表符分隔输出表格，首行为表头
+func writeTSV(w io.Writer, t tabular) error {
+	var b strings.Builder
+	writeRow := func(cells []string) {
+		for i, cell := range cells {
+			if i > 0 {
+				b.WriteByte('\t')
+			}
+			b.WriteString(strings.NewReplacer("\t", " ", "\n", " ").Replace(cell))
+		}
+		b.WriteByte('\n')
+	}
+
+	writeRow(t.Header())
+	for _, row := range t.Rows() {
+		writeRow(row)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeMarkdownTable 以 Markdown 表格输出
+func writeMarkdownTable(w io.Writer, t tabular) error {
+	var b strings.Builder
+	writeRow := func(cells []string) {
+		b.WriteString("|")
+		for _, cell := range cells {
+			cell = strings.NewReplacer("|", `\|`, "\n", " ").Replace(cell)
+			b.WriteString(" " + cell + " |")
+		}
+		b.WriteByte('\n')
+	}
+
+	header := t.Header()
+	writeRow(header)
+	b.WriteString(strings.Repeat("| --- ", len(header)) + "|\n")
+	for _, row := range t.Rows() {
+		writeRow(row)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// commandSummary 列表和搜索结果中的命令摘要
+type commandSummary struct {
+	Name            string          `json:"name" yaml:"name"`
+	Category        string          `json:"category" yaml:"category"`
+	Description     string          `json:"description" yaml:"description"`
+	Risk            model.RiskLevel `json:"risk" yaml:"risk"`
+	InstallRequired bool            `json:"install_required" yaml:"install_required"`
+	Platforms       []string        `json:"platforms" yaml:"platforms"`
+}
+
+func summarize(commands []*model.Command) []commandSummary {
+	summaries := make([]commandSummary, 0, len(commands))
+	for _, cmd := range commands {
+		platforms := cmd.Platforms
+		if platforms == nil {
+			platforms = []string{}
+		}
+		summaries = append(summaries, commandSummary{
+			Name:            cmd.Name,
+			Category:        cmd.Category,
+			Description:     cmd.Description,
+			Risk:            cmd.GetHighestRisk(),
+			InstallRequired: cmd.InstallRequired,
+			Platforms:       platforms,
+		})
+	}
+	return summaries
+}
+
+func summaryHeader() []string {
+	return []string{"name", "category", "risk", "description"}
+}
+
+func summaryRows(summaries []commandSummary) [][]string {
+	rows := make([][]string, 0, len(summaries))
+	for _, s := range summaries {
+		rows = append(rows, []string{s.Name, s.Category, string(s.Risk), s.Description})
+	}
+	return rows
+}
+
+// hostPlatform 按平台过滤时的当前平台，否则为空
+func hostPlatform() string {
+	if host := cmdService.Host(); host != nil {
+		return host.String()
+	}
+	return ""
+}
+
+// commandList list 子命令的输出
+type commandList struct {
+	Category string           `json:"category,omitempty" yaml:"category,omitempty"`
+	Platform string           `json:"platform,omitempty" yaml:"platform,omitempty"`
+	Total    int              `json:"total" yaml:"total"`
+	Commands []commandSummary `json:"commands" yaml:"commands"`
+}
+
+func (l *commandList) Header() []string { return summaryHeader() }
+func (l *commandList) Rows() [][]string { return summaryRows(l.Commands) }
+
+// snippetSummary 搜索结果中的个人片段
+type snippetSummary struct {
+	Name        string `json:"name" yaml:"name"`
+	CommandName string `json:"command_name" yaml:"command_name"`
+	Command     string `json:"command" yaml:"command"`
+	Description string `json:"description" yaml:"description"`
+}
+
+// searchResult search 子命令的输出
+type searchResult struct {
+	Query    string           `json:"query" yaml:"query"`
+	Platform string           `json:"platform,omitempty" yaml:"platform,omitempty"`
+	Total    int              `json:"total" yaml:"total"`
+	Commands []commandSummary `json:"commands" yaml:"commands"`
+	Snippets []snippetSummary `json:"snippets,omitempty" yaml:"snippets,omitempty"`
+}
+
+func (r *searchResult) Header() []string { return summaryHeader() }
+func (r *searchResult) Rows() [][]string { return summaryRows(r.Commands) }
+
+// categorySummary 分类及其命令数量
+type categorySummary struct {
+	Name  string `json:"name" yaml:"name"`
+	Count int    `json:"count" yaml:"count"`
+}
+
+// categoryList categories 子命令的输出
+type categoryList struct {
+	Total      int               `json:"total" yaml:"total"`
+	Categories []categorySummary `json:"categories" yaml:"categories"`
+}
+
+func (l *categoryList) Header() []string { return []string{"name", "count"} }
+
+func (l *categoryList) Rows() [][]string {
+	rows := make([][]string, 0, len(l.Categories))
+	for _, c := range l.Categories {
+		rows = append(rows, []string{c.Name, fmt.Sprint(c.Count)})
+	}
+	return rows
+}
+
+// commandDetail show 子命令的输出，JSON 和 YAML 结构与数据文件中的命令一致
+type commandDetail model.Command
+
+func (d *commandDetail) Header() []string { return []string{"field", "value"} }
+
+func (d *commandDetail) Rows() [][]string {
+	cmd := (*model.Command)(d)
+	rows := [][]string{
+		{"name", cmd.Name},
+		{"category", cmd.Category},
+		{"description", cmd.Description},
+		{"risk", string(cmd.GetHighestRisk())},
+		{"platforms", strings.Join(cmd.Platforms, ",")},
+	}
+	for _, usage := range cmd.Usage {
+		rows = append(rows, []string{"usage", usage})
+	}
+	for _, opt := range cmd.Options {
+		rows = append(rows, []string{"option", opt.Flag + " " + opt.Description})
+	}
+	for _, ex := range cmd.Examples {
+		rows = append(rows, []string{"example", ex.Command})
+	}
+	return rows
+}
+
+func (d *commandDetail) WriteMarkdown(w io.Writer) error {
+	return export.WriteCommandMarkdown(w, (*model.Command)(d))
+}
+
+// versionInfo version 子命令的输出
+type versionInfo struct {
+	Version     string `json:"version" yaml:"version"`
+	BuildTime   string `json:"build_time" yaml:"build_time"`
+	Commit      string `json:"commit" yaml:"commit"`
+	DataVersion string `json:"data_version,omitempty" yaml:"data_version,omitempty"`
+	DataUpdated string `json:"data_updated,omitempty" yaml:"data_updated,omitempty"`
+	Commands    int    `json:"commands" yaml:"commands"`
+	Categories  int    `json:"categories" yaml:"categories"`
+}
+
+func (v *versionInfo) Header() []string { return []string{"field", "value"} }
+
+func (v *versionInfo) Rows() [][]string {
+	return [][]string{
+		{"version", v.Version},
+		{"build_time", v.BuildTime},
+		{"commit", v.Commit},
+		{"data_version", v.DataVersion},
+		{"data_updated", v.DataUpdated},
+		{"commands", fmt.Sprint(v.Commands)},
+		{"categories", fmt.Sprint(v.Categories)},
+	}
+}
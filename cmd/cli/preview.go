@@ -0,0 +1,79 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cmd4coder/cmd4coder/internal/model"
+	"github.com/spf13/cobra"
+)
+
+var previewRaw bool
+
+var previewCmd = &cobra.Command{
+	Use:   "preview <command line...>",
+	Short: "生成命令的预演（dry-run）形式",
+	Long: `识别命令行对应的命令，按其 dry_run 定义插入预演选项（如 kubectl 的 --dry-run=client、ansible-playbook 的 --check），先看效果再执行。
+命令行可以作为一个带引号的参数传入；分成多个参数时按 shell 规则引用含空格或特殊字符的参数后拼接。`,
+	Example: `  cmd4coder preview kubectl delete -f deployment.yaml
+  cmd4coder preview -- ansible-playbook site.yml -i hosts
+  cmd4coder preview --raw "helm upgrade api ./chart"`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// 单个参数即完整命令行，多个参数需要重新引用才能还原原命令
+		line := args[0]
+		if len(args) > 1 {
+			line = model.ShellJoin(args)
+		}
+
+		command, preview, err := cmdService.PreviewCommandLine(line)
+		if err != nil {
+			var notFound model.ErrCommandNotFound
+			if errors.As(err, &notFound) {
+				return fmt.Errorf("未识别命令行对应的命令: %s", line)
+			}
+			var noDryRun model.ErrNoDryRun
+			if errors.As(err, &noDryRun) {
+				if !previewRaw {
+					printNoDryRun(command)
+				}
+				return fmt.Errorf("命令 '%s' 没有预演方式", command.Name)
+			}
+			return err
+		}
+
+		if previewRaw {
+			fmt.Println(preview)
+			return nil
+		}
+
+		fmt.Printf("🔍 预演: %s %s\n", command.Name, getRiskIndicator(command.GetRiskLevel()))
+		fmt.Printf("  原命令: %s\n", strings.TrimSpace(line))
+		fmt.Printf("  预演:   $ %s\n", preview)
+		if command.DryRun.Note != "" {
+			fmt.Printf("  💬 %s\n", command.DryRun.Note)
+		}
+		return nil
+	},
+}
+
+func init() {
+	// 第一个参数之后的选项都属于被预演的命令行
+	previewCmd.Flags().SetInterspersed(false)
+	previewCmd.Flags().BoolVar(&previewRaw, "raw", false, "只输出改写后的命令行")
+}
+
+// printNoDryRun 命令没有预演方式时提示更安全的替代做法
+func printNoDryRun(command *model.Command) {
+	if len(command.SaferAlternatives) == 0 {
+		return
+	}
+	fmt.Printf("🛡️  %s 没有预演选项，可以考虑:\n", command.Name)
+	for _, alt := range command.SaferAlternatives {
+		fmt.Printf("  • %s\n", alt.Description)
+		if alt.Example != "" {
+			fmt.Printf("    $ %s\n", alt.Example)
+		}
+	}
+}
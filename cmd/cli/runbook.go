@@ -0,0 +1,383 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cmd4coder/cmd4coder/internal/model"
+	"github.com/cmd4coder/cmd4coder/pkg/executor"
+	"github.com/spf13/cobra"
+)
+
+var (
+	runbookSet           map[string]string
+	runbookFrom          int
+	runbookTranscriptDir string
+	runbookTimeout       time.Duration
+)
+
+var runbookCmd = &cobra.Command{
+	Use:   "runbook",
+	Short: "运行手册",
+	Long:  `查看和逐步执行由数据集命令组成的运行手册（如 Pod 排障：查看状态 → describe → 日志 → 回滚）`,
+}
+
+var runbookListCmd = &cobra.Command{
+	Use:     "list",
+	Short:   "列出运行手册",
+	Example: `  cmd4coder runbook list`,
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		runbooks := cmdService.ListRunbooks()
+		if len(runbooks) == 0 {
+			fmt.Println("没有可用的运行手册")
+			return nil
+		}
+
+		fmt.Printf("\n运行手册 (共 %d 个)\n", len(runbooks))
+		fmt.Println(strings.Repeat("=", 80))
+
+		for _, r := range runbooks {
+			fmt.Printf("%-28s %-6s %s\n", r.ID, fmt.Sprintf("%d步", len(r.Steps)), r.Title)
+		}
+
+		fmt.Println()
+		fmt.Println("使用 'cmd4coder runbook show <ID>' 查看步骤，'cmd4coder runbook step <ID>' 逐步执行")
+
+		return nil
+	},
+}
+
+var runbookShowCmd = &cobra.Command{
+	Use:   "show <id>",
+	Short: "显示运行手册的所有步骤",
+	Long:  `显示运行手册的所有步骤，--set 指定的参数会填入步骤命令行`,
+	Example: `  cmd4coder runbook show k8s-pod-crashloop
+  cmd4coder runbook show k8s-pod-crashloop --set namespace=payments --set deployment=api`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		runbook, err := cmdService.GetRunbook(args[0])
+		if err != nil {
+			return fmt.Errorf("运行手册 '%s' 未找到", args[0])
+		}
+
+		printRunbookHeader(runbook)
+		for i := range runbook.Steps {
+			printRunbookStep(runbook, i)
+		}
+		fmt.Println()
+
+		return nil
+	},
+}
+
+var runbookStepCmd = &cobra.Command{
+	Use:   "step <id>",
+	Short: "逐步执行运行手册",
+	Long:  `一次显示一个步骤，按回车进入下一步；也可以输入步骤序号或步骤ID跳转，用于按分支处理`,
+	Example: `  cmd4coder runbook step k8s-pod-crashloop --set namespace=payments
+  cmd4coder runbook step k8s-pod-crashloop --from 3`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		runbook, err := cmdService.GetRunbook(args[0])
+		if err != nil {
+			return fmt.Errorf("运行手册 '%s' 未找到", args[0])
+		}
+		if runbookFrom < 1 || runbookFrom > len(runbook.Steps) {
+			return fmt.Errorf("--from 必须在 1 到 %d 之间", len(runbook.Steps))
+		}
+
+		printRunbookHeader(runbook)
+
+		reader := bufio.NewReader(os.Stdin)
+		current := runbookFrom - 1
+		for {
+			printRunbookStep(runbook, current)
+
+			input, ok := prompt(reader, "\n[回车/n] 下一步  [p] 上一步  [序号/步骤ID] 跳转  [q] 退出: ")
+			if !ok {
+				return nil
+			}
+
+			switch {
+			case input == "" || input == "n":
+				if current == len(runbook.Steps)-1 {
+					fmt.Println("\n✅ 已完成所有步骤")
+					return nil
+				}
+				current++
+			case input == "p":
+				if current > 0 {
+					current--
+				}
+			case input == "q":
+				return nil
+			default:
+				next := runbookStepTarget(runbook, input)
+				if next < 0 {
+					fmt.Printf("⚠️  未知的步骤: %s\n", input)
+					continue
+				}
+				current = next
+			}
+		}
+	},
+}
+
+var runbookRunCmd = &cobra.Command{
+	Use:   "run <id>",
+	Short: "执行运行手册并记录过程",
+	Long: `逐步执行运行手册中的命令，捕获标准输出、标准错误、退出码和耗时。
+高风险和严重风险步骤（以及数据集中未收录的命令）需要输入 yes 确认后才会执行，风险取步骤引用的命令和实际运行的命令行中较高的一个；仍有未填充参数的步骤不会执行。
+结束后在 --transcript-dir 下写出带时间戳的 Markdown 和 JSON 执行记录，可附到事故复盘中。`,
+	Example: `  cmd4coder runbook run linux-service-down --set service=nginx
+  cmd4coder runbook run k8s-pod-crashloop --set namespace=payments --set deployment=api --set pod=api-0 --transcript-dir ./postmortem`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		runbook, err := cmdService.GetRunbook(args[0])
+		if err != nil {
+			return fmt.Errorf("运行手册 '%s' 未找到", args[0])
+		}
+		if runbookFrom < 1 || runbookFrom > len(runbook.Steps) {
+			return fmt.Errorf("--from 必须在 1 到 %d 之间", len(runbook.Steps))
+		}
+
+		printRunbookHeader(runbook)
+
+		runner := executor.NewRunner()
+		runner.Timeout = runbookTimeout
+		runner.Stdout = os.Stdout
+		runner.Stderr = os.Stderr
+
+		transcript := executor.NewTranscript(runbook.ID, runbook.Title, runbook.ParamValues(runbookSet))
+		reader := bufio.NewReader(os.Stdin)
+
+		for current := runbookFrom - 1; current < len(runbook.Steps); {
+			printRunbookStep(runbook, current)
+
+			input, ok := prompt(reader, "\n[回车] 执行  [s] 跳过  [序号/步骤ID] 跳转  [q] 结束: ")
+			if !ok || input == "q" {
+				break
+			}
+
+			switch input {
+			case "":
+				transcript.Record(runRunbookStep(runner, reader, runbook, current))
+				current++
+			case "s":
+				transcript.Record(newStepRecord(runbook, current, executor.StepSkipped, "手动跳过"))
+				current++
+			default:
+				next := runbookStepTarget(runbook, input)
+				if next < 0 {
+					fmt.Printf("⚠️  未知的步骤: %s\n", input)
+					continue
+				}
+				current = next
+			}
+		}
+
+		transcript.Finish()
+		if len(transcript.Steps) == 0 {
+			fmt.Println("\n未执行任何步骤，不生成执行记录")
+			return nil
+		}
+
+		mdPath, jsonPath, err := transcript.Save(runbookTranscriptDir)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("\n📄 执行记录已保存:\n  %s\n  %s\n", mdPath, jsonPath)
+
+		return nil
+	},
+}
+
+// runRunbookStep 确认并执行一个步骤，返回执行记录
+func runRunbookStep(runner *executor.Runner, reader *bufio.Reader, runbook *model.Runbook, i int) executor.StepRecord {
+	record := newStepRecord(runbook, i, executor.StepExecuted, "")
+
+	if err := runner.Check(record.Run); err != nil {
+		fmt.Printf("⛔ 未执行: %v\n", err)
+		record.Status = executor.StepBlocked
+		record.Note = err.Error()
+		return record
+	}
+
+	// 高风险、严重风险以及未收录的命令需要明确确认
+	risk := model.RiskLevel(record.Risk)
+	if risk == model.RiskLevelHigh || risk == model.RiskLevelCritical || record.Risk == "" {
+		input, _ := prompt(reader, fmt.Sprintf("⚠️  该步骤风险为 %s，输入 yes 确认执行: ", riskLabel(record.Risk)))
+		if input != "yes" {
+			fmt.Println("已取消执行该步骤")
+			record.Status = executor.StepDeclined
+			record.Note = "未确认高风险操作"
+			return record
+		}
+	}
+
+	// 执行期间 Ctrl+C 只终止当前命令
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	fmt.Printf("\n▶ %s\n", record.Run)
+	result, err := runner.Run(ctx, record.Run)
+	if err != nil {
+		fmt.Printf("⛔ 未执行: %v\n", err)
+		record.Status = executor.StepBlocked
+		record.Note = err.Error()
+		return record
+	}
+	record.Result = result
+
+	switch {
+	case result.TimedOut:
+		fmt.Printf("\n⏱️  超时终止（%s）\n", result.Duration.Round(time.Millisecond))
+	case result.Error != "":
+		fmt.Printf("\n❌ 执行失败: %s\n", result.Error)
+	case result.Success():
+		fmt.Printf("\n✅ 退出码 0，耗时 %s\n", result.Duration.Round(time.Millisecond))
+	default:
+		fmt.Printf("\n❌ 退出码 %d，耗时 %s\n", result.ExitCode, result.Duration.Round(time.Millisecond))
+	}
+	if !result.Success() && len(runbook.Steps[i].Branches) > 0 {
+		fmt.Println("   根据上面的分支说明决定下一步，可输入步骤ID跳转")
+	}
+
+	return record
+}
+
+// newStepRecord 创建步骤记录，风险级别取引用的命令和实际运行的命令行中较高的一个，未收录的命令风险为空
+func newStepRecord(runbook *model.Runbook, i int, status, note string) executor.StepRecord {
+	step := runbook.Steps[i]
+	run := runbook.RenderStep(i, runbookSet)
+
+	return executor.StepRecord{
+		Index:   i + 1,
+		Title:   step.Title,
+		Command: step.Command,
+		Risk:    string(cmdService.RunbookStepRisk(step, run)),
+		Run:     run,
+		Status:  status,
+		Note:    note,
+	}
+}
+
+// riskLabel 风险级别显示文本
+func riskLabel(risk string) string {
+	if risk == "" {
+		return "未知（命令未收录）"
+	}
+	return getRiskIndicator(model.RiskLevel(risk)) + " " + risk
+}
+
+// prompt 输出提示并读取一行输入，输入结束（EOF）时 ok 为 false
+func prompt(reader *bufio.Reader, message string) (string, bool) {
+	fmt.Print(message)
+	line, err := reader.ReadString('\n')
+	input := strings.TrimSpace(line)
+	if err != nil && input == "" {
+		fmt.Println()
+		return "", false
+	}
+	return input, true
+}
+
+// runbookStepTarget 把用户输入的序号或步骤ID转换为步骤下标，无效时返回 -1
+func runbookStepTarget(runbook *model.Runbook, input string) int {
+	if n, err := strconv.Atoi(input); err == nil {
+		if n >= 1 && n <= len(runbook.Steps) {
+			return n - 1
+		}
+		return -1
+	}
+	return runbook.StepIndex(input)
+}
+
+// printRunbookHeader 输出运行手册标题、说明和参数
+func printRunbookHeader(runbook *model.Runbook) {
+	fmt.Printf("\n运行手册: %s\n", runbook.Title)
+	fmt.Println(strings.Repeat("=", 80))
+
+	if runbook.Description != "" {
+		fmt.Printf("\n📝 描述:\n  %s\n", runbook.Description)
+	}
+
+	if len(runbook.Params) > 0 {
+		values := runbook.ParamValues(runbookSet)
+		fmt.Printf("\n🔧 参数:\n")
+		for _, p := range runbook.Params {
+			value, ok := values[p.Name]
+			if !ok {
+				value = "(未设置)"
+			}
+			fmt.Printf("  %-16s %-20s %s\n", p.Name, value, p.Description)
+		}
+		if missing := runbook.MissingParams(runbookSet); len(missing) > 0 {
+			fmt.Printf("\n⚠️  未设置的参数: %s（使用 --set 参数=值）\n", strings.Join(missing, ", "))
+		}
+	}
+
+	if len(runbook.References) > 0 {
+		fmt.Printf("\n📚 参考链接:\n")
+		for _, ref := range runbook.References {
+			fmt.Printf("  %s\n", ref)
+		}
+	}
+}
+
+// printRunbookStep 输出单个步骤
+func printRunbookStep(runbook *model.Runbook, i int) {
+	step := runbook.Steps[i]
+	run := runbook.RenderStep(i, runbookSet)
+
+	label := step.Command
+	if risk := cmdService.RunbookStepRisk(step, run); risk != "" {
+		label += " " + getRiskIndicator(risk)
+	} else {
+		label += " ⚠️ 未收录"
+	}
+
+	fmt.Printf("\n[%d/%d] %s  (%s)\n", i+1, len(runbook.Steps), step.Title, label)
+	if step.ID != "" {
+		fmt.Printf("  步骤ID: %s\n", step.ID)
+	}
+	fmt.Printf("  $ %s\n", run)
+
+	if step.Expected != "" {
+		fmt.Printf("  ✅ 预期: %s\n", step.Expected)
+	}
+
+	for _, b := range step.Branches {
+		target := ""
+		if b.Goto != "" {
+			target = fmt.Sprintf(" → 跳到步骤 %d (%s)", runbook.StepIndex(b.Goto)+1, b.Goto)
+		}
+		fmt.Printf("  🔀 %s: %s%s\n", b.When, b.Then, target)
+	}
+
+	for _, note := range step.Notes {
+		fmt.Printf("  ⚠️  %s\n", note)
+	}
+}
+
+func init() {
+	runbookShowCmd.Flags().StringToStringVar(&runbookSet, "set", nil, "参数值，如 --set namespace=prod")
+	runbookStepCmd.Flags().StringToStringVar(&runbookSet, "set", nil, "参数值，如 --set namespace=prod")
+	runbookStepCmd.Flags().IntVar(&runbookFrom, "from", 1, "从第几步开始")
+	runbookRunCmd.Flags().StringToStringVar(&runbookSet, "set", nil, "参数值，如 --set namespace=prod")
+	runbookRunCmd.Flags().IntVar(&runbookFrom, "from", 1, "从第几步开始")
+	runbookRunCmd.Flags().StringVar(&runbookTranscriptDir, "transcript-dir", ".", "执行记录输出目录")
+	runbookRunCmd.Flags().DurationVar(&runbookTimeout, "timeout", 10*time.Minute, "单个步骤的超时时间，0 表示不限制")
+
+	runbookCmd.AddCommand(runbookListCmd)
+	runbookCmd.AddCommand(runbookShowCmd)
+	runbookCmd.AddCommand(runbookStepCmd)
+	runbookCmd.AddCommand(runbookRunCmd)
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cmd4coder/cmd4coder/internal/model"
+	"github.com/spf13/cobra"
+)
+
+var (
+	snippetName    string
+	snippetDesc    string
+	snippetTags    []string
+	snippetVars    map[string]string
+	snippetCommand string
+	snippetTag     string
+	snippetSet     map[string]string
+)
+
+var snippetCmd = &cobra.Command{
+	Use:               "snippet",
+	Short:             "管理个人命令片段",
+	Long:              `保存自己常用的具体命令写法（如带固定命名空间的 kubectl port-forward），关联到来源命令，支持描述、标签和 {{变量}}`,
+	PersistentPreRunE: requireUserData,
+}
+
+var snippetAddCmd = &cobra.Command{
+	Use:   "add <command> -- <command line...>",
+	Short: "添加片段",
+	Long:  `添加个人命令片段，命令行中可用 {{变量}} 占位，--var 设置变量默认值`,
+	Example: `  cmd4coder snippet add "kubectl port-forward" --name pf-api --desc "转发支付 API" -- kubectl port-forward -n payments svc/api 8080:80
+  cmd4coder snippet add psql --tag db --var host=db.internal -- psql -h {{host}} -U readonly {{db}}`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		command, err := cmdService.GetCommand(args[0])
+		if err != nil {
+			return fmt.Errorf("命令 '%s' 未找到", args[0])
+		}
+
+		snippet, err := cfgService.AddSnippet(model.Snippet{
+			Name:        snippetName,
+			CommandName: command.Name,
+			Command:     model.ShellJoin(args[1:]),
+			Description: snippetDesc,
+			Tags:        snippetTags,
+			Variables:   snippetVars,
+		})
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("📌 已保存片段 %s (%s)\n", snippet.Name, command.Name)
+		fmt.Printf("  $ %s\n", snippet.Command)
+		return nil
+	},
+}
+
+var snippetRmCmd = &cobra.Command{
+	Use:     "rm <name>",
+	Short:   "删除片段",
+	Example: `  cmd4coder snippet rm pf-api`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := cfgService.RemoveSnippet(args[0]); err != nil {
+			return err
+		}
+		fmt.Printf("✅ 已删除片段: %s\n", args[0])
+		return nil
+	},
+}
+
+var snippetLsCmd = &cobra.Command{
+	Use:   "ls",
+	Short: "列出片段",
+	Long:  `列出个人命令片段，可按来源命令或标签筛选`,
+	Example: `  cmd4coder snippet ls
+  cmd4coder snippet ls --command "kubectl port-forward"
+  cmd4coder snippet ls --tag db`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		snippets := cfgService.FilterSnippets(snippetCommand, snippetTag)
+		if len(snippets) == 0 {
+			fmt.Println("没有符合条件的片段")
+			return nil
+		}
+
+		fmt.Printf("\n个人片段 (共 %d 个)\n", len(snippets))
+		fmt.Println(strings.Repeat("=", 80))
+		printSnippets(snippets, true)
+		fmt.Println()
+		fmt.Println("使用 'cmd4coder snippet show <片段名> --set 变量=值' 填充变量")
+
+		return nil
+	},
+}
+
+var snippetShowCmd = &cobra.Command{
+	Use:   "show <name>",
+	Short: "显示片段并填充变量",
+	Long:  `显示片段，--set 指定的变量值优先于保存的默认值`,
+	Example: `  cmd4coder snippet show pf-api
+  cmd4coder snippet show snippet-2 --set db=orders`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		snippet := cfgService.FindSnippet(args[0])
+		if snippet == nil {
+			return fmt.Errorf("片段不存在: %s", args[0])
+		}
+
+		fmt.Printf("\n片段: %s (%s)\n", snippet.Name, snippet.CommandName)
+		fmt.Println(strings.Repeat("=", 80))
+		if snippet.Description != "" {
+			fmt.Printf("\n📝 描述:\n  %s\n", snippet.Description)
+		}
+		if len(snippet.Tags) > 0 {
+			fmt.Printf("\n🏷️  标签: %s\n", strings.Join(snippet.Tags, ", "))
+		}
+
+		var missing []string
+		for _, name := range snippet.Placeholders() {
+			_, set := snippetSet[name]
+			_, hasDefault := snippet.Variables[name]
+			if !set && !hasDefault {
+				missing = append(missing, name)
+			}
+		}
+
+		fmt.Printf("\n💡 命令:\n  $ %s\n", snippet.Render(snippetSet))
+		if len(missing) > 0 {
+			fmt.Printf("\n⚠️  未填充的变量: %s（使用 --set 变量=值）\n", strings.Join(missing, ", "))
+		}
+		fmt.Println()
+
+		return nil
+	},
+}
+
+// printSnippets 输出片段列表，showCommand 为 true 时同时显示来源命令
+func printSnippets(snippets []model.Snippet, showCommand bool) {
+	for _, snippet := range snippets {
+		title := snippet.Name
+		if showCommand {
+			title = fmt.Sprintf("%s (%s)", snippet.Name, snippet.CommandName)
+		}
+		if snippet.Description != "" {
+			title += ": " + snippet.Description
+		}
+		fmt.Printf("\n  %s\n", title)
+		fmt.Printf("  $ %s\n", snippet.Command)
+		if len(snippet.Tags) > 0 {
+			fmt.Printf("  🏷️  %s\n", strings.Join(snippet.Tags, ", "))
+		}
+	}
+}
+
+func init() {
+	snippetAddCmd.Flags().StringVarP(&snippetName, "name", "n", "", "片段名称（默认自动生成）")
+	snippetAddCmd.Flags().StringVar(&snippetDesc, "desc", "", "描述")
+	snippetAddCmd.Flags().StringSliceVarP(&snippetTags, "tag", "t", nil, "标签（可重复）")
+	snippetAddCmd.Flags().StringToStringVar(&snippetVars, "var", nil, "变量默认值，如 --var ns=prod")
+
+	snippetLsCmd.Flags().StringVarP(&snippetCommand, "command", "c", "", "按来源命令筛选")
+	snippetLsCmd.Flags().StringVarP(&snippetTag, "tag", "t", "", "按标签筛选")
+
+	snippetShowCmd.Flags().StringToStringVar(&snippetSet, "set", nil, "变量值，如 --set ns=prod")
+
+	snippetCmd.AddCommand(snippetAddCmd)
+	snippetCmd.AddCommand(snippetRmCmd)
+	snippetCmd.AddCommand(snippetLsCmd)
+	snippetCmd.AddCommand(snippetShowCmd)
+}
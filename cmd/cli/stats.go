@@ -0,0 +1,120 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cmd4coder/cmd4coder/internal/model"
+	"github.com/spf13/cobra"
+)
+
+var (
+	statsTop    int
+	statsPeriod string
+)
+
+// statsReport stats 子命令的输出结构
+type statsReport struct {
+	TotalViews          int                    `json:"total_views" yaml:"total_views"`
+	TopCommands         []model.CommandUsage   `json:"top_commands" yaml:"top_commands"`
+	CategoryTimeline    []model.CategoryPeriod `json:"category_timeline" yaml:"category_timeline"`
+	NeverViewedHighRisk []string               `json:"never_viewed_high_risk" yaml:"never_viewed_high_risk"`
+}
+
+func (r *statsReport) Header() []string { return []string{"command", "count", "last_viewed"} }
+
+func (r *statsReport) Rows() [][]string {
+	rows := make([][]string, 0, len(r.TopCommands))
+	for _, u := range r.TopCommands {
+		rows = append(rows, []string{u.Command, fmt.Sprint(u.Count), u.Last.Format(time.RFC3339)})
+	}
+	return rows
+}
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "显示个人使用统计",
+	Long:  `根据本地使用日志显示最常查看的命令、各分类随时间的查看次数，以及从未查看过的高风险命令`,
+	Example: `  cmd4coder stats
+  cmd4coder stats --period week --top 20
+  cmd4coder stats --output json | jq '.top_commands[0]'`,
+	Annotations: structuredOutput(),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if cfgService == nil {
+			return fmt.Errorf("无法加载用户数据")
+		}
+		if statsPeriod != model.PeriodWeek && statsPeriod != model.PeriodMonth {
+			return fmt.Errorf("--period 必须是 week 或 month")
+		}
+
+		usageLog := cfgService.GetUsageLog()
+		report := statsReport{
+			TotalViews:       usageLog.TotalViews(),
+			TopCommands:      usageLog.CommandUsage(),
+			CategoryTimeline: usageLog.CategoryTimeline(statsPeriod),
+		}
+		if len(report.TopCommands) > statsTop {
+			report.TopCommands = report.TopCommands[:statsTop]
+		}
+
+		viewed := usageLog.Viewed()
+		for _, c := range cmdService.GetHighRiskCommands() {
+			if !viewed[c.Name] {
+				report.NeverViewedHighRisk = append(report.NeverViewedHighRisk, c.Name)
+			}
+		}
+		sort.Strings(report.NeverViewedHighRisk)
+
+		return render(&report, func() { printStatsReport(&report) })
+	},
+}
+
+func init() {
+	statsCmd.Flags().IntVar(&statsTop, "top", 10, "显示最常查看的命令数量")
+	statsCmd.Flags().StringVar(&statsPeriod, "period", model.PeriodMonth, "分类统计周期: week|month")
+}
+
+// printStatsReport 输出文本格式的使用统计
+func printStatsReport(report *statsReport) {
+	fmt.Printf("\n使用统计 (共查看 %d 次)\n", report.TotalViews)
+	fmt.Println(strings.Repeat("=", 80))
+
+	if len(report.TopCommands) > 0 {
+		fmt.Printf("\n🔥 最常查看:\n")
+		for _, u := range report.TopCommands {
+			fmt.Printf("  %-30s %5d 次  最近: %s\n", u.Command, u.Count, u.Last.Format("2006-01-02 15:04"))
+		}
+	}
+
+	if len(report.CategoryTimeline) > 0 {
+		fmt.Printf("\n📈 分类查看趋势:\n")
+		for _, p := range report.CategoryTimeline {
+			categories := make([]string, 0, len(p.Counts))
+			for category := range p.Counts {
+				categories = append(categories, category)
+			}
+			sort.Slice(categories, func(i, j int) bool {
+				if p.Counts[categories[i]] != p.Counts[categories[j]] {
+					return p.Counts[categories[i]] > p.Counts[categories[j]]
+				}
+				return categories[i] < categories[j]
+			})
+
+			fmt.Printf("  %s\n", p.Period)
+			for _, category := range categories {
+				fmt.Printf("    %-40s %5d 次\n", category, p.Counts[category])
+			}
+		}
+	}
+
+	if len(report.NeverViewedHighRisk) > 0 {
+		fmt.Printf("\n🔴 从未查看过的高风险命令 (%d 个):\n", len(report.NeverViewedHighRisk))
+		for _, name := range report.NeverViewedHighRisk {
+			fmt.Printf("  %s\n", name)
+		}
+	}
+
+	fmt.Println()
+}
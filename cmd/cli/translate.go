@@ -0,0 +1,95 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cmd4coder/cmd4coder/internal/model"
+	"github.com/cmd4coder/cmd4coder/pkg/platform"
+	"github.com/spf13/cobra"
+)
+
+var (
+	translateTo  string
+	translateRaw bool
+)
+
+var translateCmd = &cobra.Command{
+	Use:   "translate <command line...>",
+	Short: "把命令转换为其他系统上的等价写法",
+	Long:  `识别命令行对应的命令，按声明的意图（equivalents）找到目标系统上的等价命令，并按语义映射转换选项，如 apt install -> yum install`,
+	Example: `  cmd4coder translate "apt install nginx" --to centos
+  cmd4coder translate "sudo yum remove -y nginx" --to ubuntu
+  cmd4coder translate --to macos -- apt search redis`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if translateTo == "" {
+			return fmt.Errorf("请用 --to 指定目标系统，如 centos、ubuntu、macos")
+		}
+		target, err := parseTranslateTarget(translateTo)
+		if err != nil {
+			return err
+		}
+
+		line := strings.Join(args, " ")
+		source, translations, err := cmdService.Translate(line, target)
+		if err != nil {
+			var notFound model.ErrCommandNotFound
+			if errors.As(err, &notFound) {
+				return fmt.Errorf("未识别命令行对应的命令: %s", line)
+			}
+			var noEquivalent model.ErrNoEquivalent
+			if errors.As(err, &noEquivalent) {
+				return fmt.Errorf("'%s' 在 %s 上没有已知的等价命令", source.Name, target)
+			}
+			return err
+		}
+
+		if translateRaw {
+			fmt.Println(translations[0].Line)
+			return nil
+		}
+
+		best := translations[0]
+		fmt.Printf("🔁 %s → %s [%s]\n", source.Name, best.Command, target)
+		fmt.Printf("  $ %s\n", best.Line)
+		printTranslationWarnings(best)
+
+		if len(translations) > 1 {
+			fmt.Printf("\n  其他写法:\n")
+			for _, t := range translations[1:] {
+				fmt.Printf("  $ %s\n", t.Line)
+				printTranslationWarnings(t)
+			}
+		}
+		return nil
+	},
+}
+
+func init() {
+	translateCmd.Flags().StringVar(&translateTo, "to", "", "目标系统，如 centos、ubuntu、macos、linux/fedora")
+	translateCmd.Flags().BoolVar(&translateRaw, "raw", false, "只输出最匹配的命令行")
+}
+
+// parseTranslateTarget 解析目标系统，单独的发行版名称视为 linux/<发行版>
+func parseTranslateTarget(spec string) (platform.Host, error) {
+	if host, err := platform.Parse(spec); err == nil {
+		return host, nil
+	}
+	host, err := platform.Parse("linux/" + spec)
+	if err != nil {
+		return platform.Host{}, fmt.Errorf("无效的目标系统 '%s'", spec)
+	}
+	return host, nil
+}
+
+// printTranslationWarnings 提示转换中去掉或未映射的选项
+func printTranslationWarnings(t model.Translation) {
+	if len(t.Dropped) > 0 {
+		fmt.Printf("    ⚠️  %s 没有对应写法，已去掉: %s\n", t.Command, strings.Join(t.Dropped, " "))
+	}
+	if len(t.Kept) > 0 {
+		fmt.Printf("    ℹ️  未声明映射，原样保留: %s\n", strings.Join(t.Kept, " "))
+	}
+}
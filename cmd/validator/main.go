@@ -4,7 +4,6 @@ import (
 	"flag"
 	"fmt"
 	"os"
-	"path/filepath"
 
 	"github.com/cmd4coder/cmd4coder/internal/data"
 	"github.com/cmd4coder/cmd4coder/internal/model"
@@ -66,11 +65,14 @@ func main() {
 	fmt.Println("开始验证数据文件...")
 	fmt.Println("----------------------------------------")
 
+	// 各文件的命令，全部加载后检查跨文件引用
+	fileCommands := make(map[string][]*model.Command)
+	names := make(map[string]bool)
+
 	for _, dataFile := range metadata.DataFiles {
 		report.TotalFiles++
-		filePath := filepath.Join(*dataDir, dataFile)
-
-		cmdList, err := loader.LoadCommandList(filePath)
+		// LoadCommandList 会拼接数据目录，这里传相对路径
+		cmdList, err := loader.LoadCommandList(dataFile)
 		if err != nil {
 			report.FailedFiles++
 			report.Errors = append(report.Errors, ValidationError{
@@ -102,6 +104,9 @@ func main() {
 
 		// 检查警告项
 		for _, cmd := range cmdList.Commands {
+			fileCommands[dataFile] = append(fileCommands[dataFile], cmd)
+			names[cmd.Name] = true
+
 			// 检查是否缺少install_method
 			if cmd.InstallMethod == "" {
 				report.Warnings = append(report.Warnings, ValidationWarning{
@@ -125,6 +130,15 @@ func main() {
 				})
 			}
 
+			// 检查严重风险命令是否提供了更安全的替代做法
+			if cmd.NeedsSaferAlternative() {
+				report.Warnings = append(report.Warnings, ValidationWarning{
+					File:    dataFile,
+					Command: cmd.Name,
+					Message: "严重风险命令缺少 safer_alternatives（如 dry-run 选项、交互确认或先备份）",
+				})
+			}
+
 			// 检查高风险命令是否有足够的风险说明
 			if cmd.GetHighestRisk() >= model.RiskLevelHigh {
 				if len(cmd.Risks) < 2 {
@@ -140,6 +154,24 @@ func main() {
 		fmt.Printf("✓ %s - 验证通过 (%d 个命令)\n", dataFile, len(cmdList.Commands))
 	}
 
+	// 检查更安全的替代命令是否指向数据集中的命令
+	referenceErrors := 0
+	for _, dataFile := range metadata.DataFiles {
+		for _, cmd := range fileCommands[dataFile] {
+			for _, alt := range cmd.SaferAlternatives {
+				if alt.Command != "" && !names[alt.Command] {
+					referenceErrors++
+					report.Errors = append(report.Errors, ValidationError{
+						File:    dataFile,
+						Command: cmd.Name,
+						Field:   "safer_alternatives",
+						Message: model.ErrUnknownReference{Command: cmd.Name, Field: "safer_alternatives", Target: alt.Command}.Error(),
+					})
+				}
+			}
+		}
+	}
+
 	// 输出报告
 	fmt.Println("\n========================================")
 	fmt.Println("验证报告")
@@ -208,7 +240,7 @@ func main() {
 	}
 
 	// 根据结果设置退出码
-	if report.FailedFiles > 0 {
+	if report.FailedFiles > 0 || referenceErrors > 0 {
 		os.Exit(1)
 	}
 }
@@ -1,6 +1,7 @@
 package data
 
 import (
+	"fmt"
 	"strings"
 	"sync"
 
@@ -21,6 +22,10 @@ type Index struct {
 	// 平台 -> 命令列表
 	platformIndex map[string][]*model.Command
 
+	// 按构建时的顺序排列的命令和分类
+	commands   []*model.Command
+	categories []string
+
 	mu sync.RWMutex
 }
 
@@ -35,6 +40,8 @@ func NewIndex() *Index {
 }
 
 // BuildIndex 构建索引
+//
+// 命令和分类保持传入的顺序，GetAllCommands、GetAllCategories 和 GetByCategory 按此顺序返回。
 func (idx *Index) BuildIndex(commands []*model.Command) error {
 	idx.mu.Lock()
 	defer idx.mu.Unlock()
@@ -44,6 +51,8 @@ func (idx *Index) BuildIndex(commands []*model.Command) error {
 	idx.categoryIndex = make(map[string][]*model.Command)
 	idx.keywordIndex = make(map[string][]*model.Command)
 	idx.platformIndex = make(map[string][]*model.Command)
+	idx.commands = make([]*model.Command, 0, len(commands))
+	idx.categories = nil
 
 	for _, cmd := range commands {
 		// 检查命令名称是否重复
@@ -53,8 +62,12 @@ func (idx *Index) BuildIndex(commands []*model.Command) error {
 
 		// 构建名称索引
 		idx.nameIndex[cmd.Name] = cmd
+		idx.commands = append(idx.commands, cmd)
 
 		// 构建分类索引
+		if _, exists := idx.categoryIndex[cmd.Category]; !exists {
+			idx.categories = append(idx.categories, cmd.Category)
+		}
 		idx.categoryIndex[cmd.Category] = append(idx.categoryIndex[cmd.Category], cmd)
 
 		// 构建平台索引
@@ -66,6 +79,33 @@ func (idx *Index) BuildIndex(commands []*model.Command) error {
 		idx.buildKeywordIndex(cmd)
 	}
 
+	// 更安全的替代命令必须在数据集中，否则 show 和关系图会指向不存在的命令
+	for _, cmd := range idx.commands {
+		for _, alt := range cmd.SaferAlternatives {
+			if alt.Command == "" {
+				continue
+			}
+			if _, exists := idx.nameIndex[alt.Command]; !exists {
+				return model.ErrUnknownReference{Command: cmd.Name, Field: "safer_alternatives", Target: alt.Command}
+			}
+		}
+	}
+
+	return nil
+}
+
+// ValidateRunbooks 检查运行手册步骤引用的命令都在索引中，与 safer_alternatives 的检查一致
+func (idx *Index) ValidateRunbooks(runbooks []*model.Runbook) error {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	for _, r := range runbooks {
+		for i, step := range r.Steps {
+			if _, exists := idx.nameIndex[step.Command]; !exists {
+				return model.ErrUnknownReference{Command: r.ID, Field: fmt.Sprintf("steps[%d].command", i), Target: step.Command}
+			}
+		}
+	}
 	return nil
 }
 
@@ -140,8 +180,18 @@ func (idx *Index) GetByPlatform(platform string) []*model.Command {
 	return idx.platformIndex[platform]
 }
 
+// Scorer 搜索结果的附加评分钩子，返回值叠加到匹配优先级上参与排序
+//
+// 索引本身不保存任何用户状态，个人化等排序调整由调用方通过 Scorer 注入。
+type Scorer func(cmd *model.Command) float64
+
 // Search 搜索命令
 func (idx *Index) Search(query string) []*model.Command {
+	return idx.SearchWithScorer(query, nil)
+}
+
+// SearchWithScorer 搜索命令，scorer 不为 nil 时把附加评分叠加到匹配优先级上排序
+func (idx *Index) SearchWithScorer(query string, scorer Scorer) []*model.Command {
 	idx.mu.RLock()
 	defer idx.mu.RUnlock()
 
@@ -200,6 +250,9 @@ func (idx *Index) Search(query string) []*model.Command {
 	// 转换为数组并排序
 	var results []*searchResult
 	for _, r := range resultMap {
+		if scorer != nil {
+			r.boost = scorer(r.command)
+		}
 		results = append(results, r)
 	}
 
@@ -220,10 +273,8 @@ func (idx *Index) GetAllCategories() []string {
 	idx.mu.RLock()
 	defer idx.mu.RUnlock()
 
-	categories := make([]string, 0, len(idx.categoryIndex))
-	for category := range idx.categoryIndex {
-		categories = append(categories, category)
-	}
+	categories := make([]string, len(idx.categories))
+	copy(categories, idx.categories)
 	return categories
 }
 
@@ -232,10 +283,8 @@ func (idx *Index) GetAllCommands() []*model.Command {
 	idx.mu.RLock()
 	defer idx.mu.RUnlock()
 
-	commands := make([]*model.Command, 0, len(idx.nameIndex))
-	for _, cmd := range idx.nameIndex {
-		commands = append(commands, cmd)
-	}
+	commands := make([]*model.Command, len(idx.commands))
+	copy(commands, idx.commands)
 	return commands
 }
 
@@ -243,16 +292,22 @@ func (idx *Index) GetAllCommands() []*model.Command {
 type searchResult struct {
 	command  *model.Command
 	priority int
+	boost    float64 // Scorer 附加评分
+}
+
+// score 排序用的综合评分
+func (r *searchResult) score() float64 {
+	return float64(r.priority) + r.boost
 }
 
 // sortSearchResults 对搜索结果排序
 func sortSearchResults(results []*searchResult) {
-	// 简单冒泡排序（按优先级降序）
+	// 简单冒泡排序（按评分降序）
 	for i := 0; i < len(results); i++ {
 		for j := i + 1; j < len(results); j++ {
-			if results[i].priority < results[j].priority {
+			if results[i].score() < results[j].score() {
 				results[i], results[j] = results[j], results[i]
-			} else if results[i].priority == results[j].priority {
+			} else if results[i].score() == results[j].score() {
 				// 同优先级按名称排序
 				if results[i].command.Name > results[j].command.Name {
 					results[i], results[j] = results[j], results[i]
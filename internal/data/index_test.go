@@ -0,0 +1,104 @@
+package data
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/cmd4coder/cmd4coder/internal/model"
+)
+
+func testCommand(name, description string) *model.Command {
+	return &model.Command{
+		Name:        name,
+		Category:    "测试分类",
+		Description: description,
+		Platforms:   []string{"linux"},
+		Usage:       []string{name},
+		Examples:    []model.Example{{Command: name, Description: description}},
+	}
+}
+
+func TestBuildIndexSaferAlternativeReference(t *testing.T) {
+	rm := testCommand("rm", "删除文件")
+	rm.SaferAlternatives = []model.SaferAlternative{
+		{Flags: "-i", Description: "逐个确认"},
+		{Command: "mv", Description: "先移到回收目录"},
+	}
+
+	idx := NewIndex()
+	if err := idx.BuildIndex([]*model.Command{rm, testCommand("mv", "移动文件")}); err != nil {
+		t.Fatalf("BuildIndex() error = %v", err)
+	}
+
+	err := idx.BuildIndex([]*model.Command{rm})
+	var target model.ErrUnknownReference
+	if !errors.As(err, &target) || target.Command != "rm" || target.Target != "mv" {
+		t.Errorf("BuildIndex() error = %v, expected unknown reference to mv", err)
+	}
+}
+
+func TestValidateRunbooks(t *testing.T) {
+	idx := NewIndex()
+	if err := idx.BuildIndex([]*model.Command{testCommand("kubectl get", "获取资源")}); err != nil {
+		t.Fatalf("BuildIndex() error = %v", err)
+	}
+
+	runbook := &model.Runbook{ID: "pod-status", Title: "查看 Pod 状态", Steps: []model.RunbookStep{
+		{Title: "查看状态", Command: "kubectl get", Run: "kubectl get pods"},
+	}}
+	if err := idx.ValidateRunbooks([]*model.Runbook{runbook}); err != nil {
+		t.Fatalf("ValidateRunbooks() error = %v", err)
+	}
+
+	runbook.Steps = append(runbook.Steps, model.RunbookStep{Title: "回滚", Command: "kubectl rollout undo", Run: "kubectl rollout undo deploy/api"})
+	err := idx.ValidateRunbooks([]*model.Runbook{runbook})
+	var target model.ErrUnknownReference
+	if !errors.As(err, &target) || target.Command != "pod-status" || target.Field != "steps[1].command" || target.Target != "kubectl rollout undo" {
+		t.Errorf("ValidateRunbooks() error = %v, expected unknown reference to kubectl rollout undo", err)
+	}
+}
+
+func TestSearchWithScorer(t *testing.T) {
+	idx := NewIndex()
+	commands := []*model.Command{
+		testCommand("podman", "容器管理工具"),
+		testCommand("kubectl describe pod", "查看详情"),
+		testCommand("kubectl logs", "查看 pod 日志"),
+		testCommand("ls", "列出目录"),
+	}
+	if err := idx.BuildIndex(commands); err != nil {
+		t.Fatalf("BuildIndex() error = %v", err)
+	}
+
+	names := func(commands []*model.Command) []string {
+		var names []string
+		for _, cmd := range commands {
+			names = append(names, cmd.Name)
+		}
+		return names
+	}
+
+	tests := []struct {
+		name  string
+		boost map[string]float64
+		want  []string
+	}{
+		{"前缀、包含、关键词依次排列", nil, []string{"podman", "kubectl describe pod", "kubectl logs"}},
+		{"加成可越过相邻的上一档", map[string]float64{"kubectl describe pod": 25}, []string{"kubectl describe pod", "podman", "kubectl logs"}},
+		{"加成不足档差时保持原有顺序", map[string]float64{"kubectl logs": 15}, []string{"podman", "kubectl describe pod", "kubectl logs"}},
+		{"加成不会越过两档", map[string]float64{"kubectl logs": 29.9}, []string{"podman", "kubectl logs", "kubectl describe pod"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var scorer Scorer
+			if tt.boost != nil {
+				scorer = func(cmd *model.Command) float64 { return tt.boost[cmd.Name] }
+			}
+			got := names(idx.SearchWithScorer("pod", scorer))
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("SearchWithScorer() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -4,9 +4,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"sync"
 
 	"github.com/cmd4coder/cmd4coder/internal/model"
+	"github.com/cmd4coder/cmd4coder/pkg/platform"
 	"gopkg.in/yaml.v3"
 )
 
@@ -61,6 +63,12 @@ func (l *Loader) LoadCommandList(filePath string) (*model.CommandList, error) {
 		return nil, model.ErrDataLoadFailed{File: fullPath, Err: err}
 	}
 
+	for _, cmd := range cmdList.Commands {
+		if err := NormalizePlatformOverrides(cmd); err != nil {
+			return nil, fmt.Errorf("validation errors in %s: %v", fullPath, err)
+		}
+	}
+
 	if err := cmdList.Validate(); err != nil {
 		return nil, fmt.Errorf("validation errors in %s: %v", fullPath, err)
 	}
@@ -68,6 +76,43 @@ func (l *Loader) LoadCommandList(filePath string) (*model.CommandList, error) {
 	return &cmdList, nil
 }
 
+// NormalizePlatformOverrides 统一平台覆盖的键名（如 macos -> darwin），合并同一平台的多份覆盖，
+// 并检查覆盖的平台在命令支持的平台之内
+func NormalizePlatformOverrides(cmd *model.Command) error {
+	if len(cmd.PlatformOverrides) == 0 {
+		return nil
+	}
+
+	supported := make([]string, 0, len(cmd.Platforms))
+	for _, p := range cmd.Platforms {
+		supported = append(supported, platform.Normalize(p))
+	}
+
+	// 按原键名顺序合并，保证结果稳定
+	keys := make([]string, 0, len(cmd.PlatformOverrides))
+	for key := range cmd.PlatformOverrides {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	normalized := make(map[string]model.PlatformOverride, len(keys))
+	for _, key := range keys {
+		name := platform.Normalize(key)
+		if !platform.Covers(supported, name) {
+			return model.ErrInvalidPlatformOverride{Command: cmd.Name, Platform: key, Reason: "platform not listed in platforms"}
+		}
+
+		override := cmd.PlatformOverrides[key]
+		if existing, ok := normalized[name]; ok {
+			override = existing.Merge(override)
+		}
+		normalized[name] = override
+	}
+
+	cmd.PlatformOverrides = normalized
+	return nil
+}
+
 // LoadAllCommands 加载所有命令
 func (l *Loader) LoadAllCommands() ([]*model.Command, error) {
 	// 先加载元数据
@@ -110,6 +155,55 @@ func (l *Loader) LoadAllCommands() ([]*model.Command, error) {
 	return allCommands, nil
 }
 
+// LoadRunbookList 加载单个运行手册文件
+func (l *Loader) LoadRunbookList(filePath string) (*model.RunbookList, error) {
+	fullPath := filepath.Join(l.dataDir, filePath)
+	data, err := os.ReadFile(fullPath)
+	if err != nil {
+		return nil, model.ErrDataLoadFailed{File: fullPath, Err: err}
+	}
+
+	var runbookList model.RunbookList
+	if err := yaml.Unmarshal(data, &runbookList); err != nil {
+		return nil, model.ErrDataLoadFailed{File: fullPath, Err: err}
+	}
+
+	if err := runbookList.Validate(); err != nil {
+		return nil, fmt.Errorf("validation errors in %s: %v", fullPath, err)
+	}
+
+	return &runbookList, nil
+}
+
+// LoadAllRunbooks 加载元数据中列出的所有运行手册，需在 LoadAllCommands 之后调用
+func (l *Loader) LoadAllRunbooks() ([]*model.Runbook, error) {
+	metadata := l.GetMetadata()
+	if metadata == nil {
+		var err error
+		if metadata, err = l.LoadMetadata(); err != nil {
+			return nil, err
+		}
+	}
+
+	var runbooks []*model.Runbook
+	seen := make(map[string]bool)
+	for _, file := range metadata.RunbookFiles {
+		runbookList, err := l.LoadRunbookList(file)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range runbookList.Runbooks {
+			if seen[r.ID] {
+				return nil, model.ErrInvalidRunbook{ID: r.ID, Reason: "duplicate runbook id"}
+			}
+			seen[r.ID] = true
+			runbooks = append(runbooks, r)
+		}
+	}
+
+	return runbooks, nil
+}
+
 // GetMetadata 获取元数据
 func (l *Loader) GetMetadata() *model.Metadata {
 	l.mu.RLock()
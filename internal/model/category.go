@@ -1,5 +1,7 @@
 package model
 
+import "sort"
+
 // Category 分类信息
 type Category struct {
 	ID          string   `yaml:"id" json:"id"`                                 // 分类ID
@@ -24,11 +26,12 @@ type CategoryTree struct {
 
 // Metadata 元数据
 type Metadata struct {
-	Version     string              `yaml:"version" json:"version"`         // 数据版本
-	UpdatedAt   string              `yaml:"updated_at" json:"updated_at"`   // 更新时间
-	Categories  map[string]Category `yaml:"categories" json:"categories"`   // 分类索引
-	DataFiles   []string            `yaml:"data_files" json:"data_files"`   // 数据文件列表
-	Description string              `yaml:"description" json:"description"` // 元数据描述
+	Version      string              `yaml:"version" json:"version"`                                 // 数据版本
+	UpdatedAt    string              `yaml:"updated_at" json:"updated_at"`                           // 更新时间
+	Categories   map[string]Category `yaml:"categories" json:"categories"`                           // 分类索引
+	DataFiles    []string            `yaml:"data_files" json:"data_files"`                           // 数据文件列表
+	RunbookFiles []string            `yaml:"runbook_files,omitempty" json:"runbook_files,omitempty"` // 运行手册文件列表
+	Description  string              `yaml:"description" json:"description"`                         // 元数据描述
 }
 
 // Validate 验证元数据
@@ -44,3 +47,48 @@ func (m *Metadata) Validate() error {
 	}
 	return nil
 }
+
+// CategoryOrder 分类名称到排序顺序（Order）的映射
+func (m *Metadata) CategoryOrder() map[string]int {
+	if m == nil {
+		return map[string]int{}
+	}
+	order := make(map[string]int, len(m.Categories))
+	for _, c := range m.Categories {
+		order[c.Name] = c.Order
+	}
+	return order
+}
+
+// CategoryLess 判断分类 a 是否排在 b 之前
+//
+// 按 order 中的顺序排列，顺序相同时按名称；order 中没有的分类排在最后并按名称排列。
+func CategoryLess(a, b string, order map[string]int) bool {
+	oa, okA := order[a]
+	ob, okB := order[b]
+	if okA != okB {
+		return okA
+	}
+	if oa != ob {
+		return oa < ob
+	}
+	return a < b
+}
+
+// SortCategories 按分类顺序排序分类名称
+func SortCategories(categories []string, order map[string]int) {
+	sort.Slice(categories, func(i, j int) bool {
+		return CategoryLess(categories[i], categories[j], order)
+	})
+}
+
+// SortCommands 按分类顺序排序命令，同一分类内按命令名称排序
+func SortCommands(commands []*Command, order map[string]int) {
+	sort.SliceStable(commands, func(i, j int) bool {
+		a, b := commands[i], commands[j]
+		if a.Category != b.Category {
+			return CategoryLess(a.Category, b.Category, order)
+		}
+		return a.Name < b.Name
+	})
+}
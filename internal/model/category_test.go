@@ -0,0 +1,55 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMetadata_CategoryOrder(t *testing.T) {
+	m := &Metadata{Categories: map[string]Category{
+		"os":  {ID: "os", Name: "操作系统", Order: 1},
+		"net": {ID: "net", Name: "网络工具", Order: 30},
+	}}
+	if got := m.CategoryOrder(); !reflect.DeepEqual(got, map[string]int{"操作系统": 1, "网络工具": 30}) {
+		t.Errorf("CategoryOrder() = %v", got)
+	}
+
+	var nilMeta *Metadata
+	if got := nilMeta.CategoryOrder(); len(got) != 0 {
+		t.Errorf("CategoryOrder() on nil metadata = %v", got)
+	}
+}
+
+func TestSortCategories(t *testing.T) {
+	order := map[string]int{"网络工具": 30, "操作系统": 1, "数据库": 30}
+	categories := []string{"Kubernetes Storage", "网络工具", "Database", "数据库", "操作系统"}
+
+	SortCategories(categories, order)
+
+	want := []string{"操作系统", "数据库", "网络工具", "Database", "Kubernetes Storage"}
+	if !reflect.DeepEqual(categories, want) {
+		t.Errorf("SortCategories() = %v, want %v", categories, want)
+	}
+}
+
+func TestSortCommands(t *testing.T) {
+	order := map[string]int{"操作系统": 1, "网络工具": 2}
+	commands := []*Command{
+		{Name: "ping", Category: "网络工具"},
+		{Name: "kubectl", Category: "Kubernetes"},
+		{Name: "ls", Category: "操作系统"},
+		{Name: "curl", Category: "网络工具"},
+		{Name: "cd", Category: "操作系统"},
+	}
+
+	SortCommands(commands, order)
+
+	var names []string
+	for _, cmd := range commands {
+		names = append(names, cmd.Name)
+	}
+	want := []string{"cd", "ls", "curl", "ping", "kubectl"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("SortCommands() = %v, want %v", names, want)
+	}
+}
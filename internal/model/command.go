@@ -22,6 +22,11 @@ func (r RiskLevel) IsValid() bool {
 	}
 }
 
+// AtLeast 检查风险级别是否不低于指定级别
+func (r RiskLevel) AtLeast(min RiskLevel) bool {
+	return riskLevelValue(r) >= riskLevelValue(min)
+}
+
 // Risk 风险描述
 type Risk struct {
 	Level       RiskLevel `yaml:"level" json:"level"`             // 风险级别
@@ -41,6 +46,20 @@ type Example struct {
 	Output      string `yaml:"output,omitempty" json:"output,omitempty"` // 预期输出（可选）
 }
 
+// SaferAlternative 高风险命令的更安全做法
+type SaferAlternative struct {
+	Command     string `yaml:"command,omitempty" json:"command,omitempty"` // 替代命令名称（数据集中的命令）
+	Flags       string `yaml:"flags,omitempty" json:"flags,omitempty"`     // 更安全的选项组合，如 --dry-run、-i
+	Example     string `yaml:"example,omitempty" json:"example,omitempty"` // 示例命令行
+	Description string `yaml:"description" json:"description"`             // 为什么更安全
+}
+
+// DryRun 命令的预演方式
+type DryRun struct {
+	Flag string `yaml:"flag" json:"flag"`                     // 预演选项，插在命令名之后，如 --dry-run=client、-n、--check
+	Note string `yaml:"note,omitempty" json:"note,omitempty"` // 预演的局限或注意事项
+}
+
 // VersionInfo 版本信息
 type VersionInfo struct {
 	MinVersion string `yaml:"min_version,omitempty" json:"min_version,omitempty"` // 最低版本
@@ -50,20 +69,25 @@ type VersionInfo struct {
 
 // Command 命令结构
 type Command struct {
-	Name            string       `yaml:"name" json:"name"`                                             // 命令名称
-	Category        string       `yaml:"category" json:"category"`                                     // 所属分类
-	InstallRequired bool         `yaml:"install_required" json:"install_required"`                     // 是否需要单独安装
-	InstallMethod   string       `yaml:"install_method,omitempty" json:"install_method,omitempty"`     // 安装方式说明
-	Description     string       `yaml:"description" json:"description"`                               // 命令功能简述
-	Usage           []string     `yaml:"usage" json:"usage"`                                           // 常用使用方式
-	Options         []Option     `yaml:"options" json:"options"`                                       // 常用选项说明
-	Examples        []Example    `yaml:"examples" json:"examples"`                                     // 使用示例
-	Notes           []string     `yaml:"notes,omitempty" json:"notes,omitempty"`                       // 注意事项
-	Risks           []Risk       `yaml:"risks,omitempty" json:"risks,omitempty"`                       // 风险说明
-	RelatedCommands []string     `yaml:"related_commands,omitempty" json:"related_commands,omitempty"` // 相关命令
-	Platforms       []string     `yaml:"platforms" json:"platforms"`                                   // 支持的平台
-	Versions        *VersionInfo `yaml:"versions,omitempty" json:"versions,omitempty"`                 // 版本兼容性说明
-	References      []string     `yaml:"references,omitempty" json:"references,omitempty"`             // 参考链接
+	Name              string                      `yaml:"name" json:"name"`                                                 // 命令名称
+	Category          string                      `yaml:"category" json:"category"`                                         // 所属分类
+	InstallRequired   bool                        `yaml:"install_required" json:"install_required"`                         // 是否需要单独安装
+	InstallMethod     string                      `yaml:"install_method,omitempty" json:"install_method,omitempty"`         // 安装方式说明
+	Description       string                      `yaml:"description" json:"description"`                                   // 命令功能简述
+	Usage             []string                    `yaml:"usage" json:"usage"`                                               // 常用使用方式
+	Options           []Option                    `yaml:"options" json:"options"`                                           // 常用选项说明
+	Examples          []Example                   `yaml:"examples" json:"examples"`                                         // 使用示例
+	Notes             []string                    `yaml:"notes,omitempty" json:"notes,omitempty"`                           // 注意事项
+	Risks             []Risk                      `yaml:"risks,omitempty" json:"risks,omitempty"`                           // 风险说明
+	SaferAlternatives []SaferAlternative          `yaml:"safer_alternatives,omitempty" json:"safer_alternatives,omitempty"` // 更安全的替代做法
+	DryRun            *DryRun                     `yaml:"dry_run,omitempty" json:"dry_run,omitempty"`                       // 预演方式
+	Equivalents       *Equivalence                `yaml:"equivalents,omitempty" json:"equivalents,omitempty"`               // 意图和语义选项，用于跨系统的等价命令转换
+	RelatedCommands   []string                    `yaml:"related_commands,omitempty" json:"related_commands,omitempty"`     // 相关命令
+	Platforms         []string                    `yaml:"platforms" json:"platforms"`                                       // 支持的平台
+	Distros           []string                    `yaml:"distros,omitempty" json:"distros,omitempty"`                       // 限定的 Linux 发行版，为空表示不限
+	PlatformOverrides map[string]PlatformOverride `yaml:"platform_overrides,omitempty" json:"platform_overrides,omitempty"` // 按平台覆盖的用法、选项和示例
+	Versions          *VersionInfo                `yaml:"versions,omitempty" json:"versions,omitempty"`                     // 版本兼容性说明
+	References        []string                    `yaml:"references,omitempty" json:"references,omitempty"`                 // 参考链接
 }
 
 // Validate 验证命令数据完整性
@@ -98,6 +122,30 @@ func (c *Command) Validate() error {
 		}
 	}
 
+	// 替代做法需要说明，并至少给出命令、选项或示例之一
+	for _, alt := range c.SaferAlternatives {
+		if alt.Description == "" {
+			return ErrMissingField{Field: "safer_alternatives.description"}
+		}
+		if alt.Command == "" && alt.Flags == "" && alt.Example == "" {
+			return ErrMissingField{Field: "safer_alternatives.command"}
+		}
+	}
+
+	for platform, override := range c.PlatformOverrides {
+		if override.IsEmpty() {
+			return ErrInvalidPlatformOverride{Command: c.Name, Platform: platform, Reason: "empty override"}
+		}
+	}
+
+	if c.Equivalents != nil && c.Equivalents.Intent == "" {
+		return ErrMissingField{Field: "equivalents.intent"}
+	}
+
+	if c.DryRun != nil && c.DryRun.Flag == "" {
+		return ErrMissingField{Field: "dry_run.flag"}
+	}
+
 	return nil
 }
 
@@ -121,6 +169,24 @@ func (c *Command) GetHighestRisk() RiskLevel {
 	return c.GetRiskLevel()
 }
 
+// IsHighRisk 是否为高风险或严重风险命令
+func (c *Command) IsHighRisk() bool {
+	return riskLevelValue(c.GetRiskLevel()) >= riskLevelValue(RiskLevelHigh)
+}
+
+// NeedsSaferAlternative 严重风险命令没有提供更安全的替代做法
+func (c *Command) NeedsSaferAlternative() bool {
+	return c.GetRiskLevel() == RiskLevelCritical && len(c.SaferAlternatives) == 0
+}
+
+// HigherRisk 返回两个风险级别中较高的一个，未知级别视为最低
+func HigherRisk(a, b RiskLevel) RiskLevel {
+	if riskLevelValue(b) > riskLevelValue(a) {
+		return b
+	}
+	return a
+}
+
 // riskLevelValue 获取风险级别的数值表示
 func riskLevelValue(level RiskLevel) int {
 	switch level {
@@ -80,6 +80,19 @@ func TestCommand_Validate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "safer alternative without target",
+			cmd: Command{
+				Name:              "test",
+				Category:          "test",
+				Description:       "test",
+				Platforms:         []string{"linux"},
+				Usage:             []string{"test"},
+				Examples:          []Example{{Command: "test", Description: "test"}},
+				SaferAlternatives: []SaferAlternative{{Description: "先备份"}},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -115,6 +128,18 @@ func TestRiskLevel_IsValid(t *testing.T) {
 	}
 }
 
+func TestRiskLevel_AtLeast(t *testing.T) {
+	if !RiskLevelCritical.AtLeast(RiskLevelHigh) || !RiskLevelHigh.AtLeast(RiskLevelHigh) {
+		t.Error("Expected critical and high to be at least high")
+	}
+	if RiskLevelMedium.AtLeast(RiskLevelHigh) {
+		t.Error("Expected medium to be below high")
+	}
+	if !RiskLevel("").AtLeast("") {
+		t.Error("Expected any level to satisfy an empty minimum")
+	}
+}
+
 func TestCommand_GetRiskLevel(t *testing.T) {
 	tests := []struct {
 		name string
@@ -164,6 +189,25 @@ func TestCommand_GetRiskLevel(t *testing.T) {
 	}
 }
 
+func TestCommand_NeedsSaferAlternative(t *testing.T) {
+	cmd := Command{
+		Risks: []Risk{{Level: RiskLevelCritical, Description: "permanently deletes data"}},
+	}
+	if !cmd.IsHighRisk() || !cmd.NeedsSaferAlternative() {
+		t.Error("Critical command without alternatives should need one")
+	}
+
+	cmd.SaferAlternatives = []SaferAlternative{{Command: "pg_dump", Description: "先备份"}}
+	if cmd.NeedsSaferAlternative() {
+		t.Error("Critical command with alternatives should not need one")
+	}
+
+	cmd.Risks[0].Level = RiskLevelMedium
+	if cmd.IsHighRisk() {
+		t.Error("Medium risk command should not be high risk")
+	}
+}
+
 func TestCommand_SupportsPlatform(t *testing.T) {
 	cmd := Command{
 		Platforms: []string{"linux", "darwin"},
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -51,11 +53,20 @@ type SearchConfig struct {
 
 // ExportConfig 导出配置
 type ExportConfig struct {
-	DefaultFormat string `json:"default_format"` // 默认格式: markdown/json
+	DefaultFormat string `json:"default_format"` // 默认格式，取值见 export.Formats()
 	OutputDir     string `json:"output_dir"`     // 输出目录
 	IncludeDate   bool   `json:"include_date"`   // 包含日期
 }
 
+// OutputPath 按配置生成导出文件路径，如 <output_dir>/commands-2026-10-19.md
+func (c ExportConfig) OutputPath(base, ext string, now time.Time) string {
+	name := base
+	if c.IncludeDate {
+		name += "-" + now.Format("2006-01-02")
+	}
+	return filepath.Join(c.OutputDir, name+"."+ext)
+}
+
 // UserData 用户数据
 type UserData struct {
 	// 收藏的命令
@@ -64,16 +75,31 @@ type UserData struct {
 	// 历史记录
 	History []HistoryEntry `json:"history"`
 
+	// 个人命令片段
+	Snippets []Snippet `json:"snippets,omitempty"`
+
 	// 最后更新时间
 	LastUpdated time.Time `json:"last_updated"`
 }
 
 // Favorite 收藏的命令
 type Favorite struct {
-	CommandName string    `json:"command_name"` // 命令名称
-	Category    string    `json:"category"`     // 分类
-	Note        string    `json:"note"`         // 备注
-	AddedAt     time.Time `json:"added_at"`     // 添加时间
+	CommandName string    `json:"command_name"`          // 命令名称
+	Category    string    `json:"category"`              // 分类
+	Note        string    `json:"note"`                  // 备注
+	Tags        []string  `json:"tags,omitempty"`        // 标签
+	Collections []string  `json:"collections,omitempty"` // 所属收藏集，如 oncall、db-migration
+	AddedAt     time.Time `json:"added_at"`              // 添加时间
+}
+
+// HasTag 检查收藏是否带有指定标签
+func (f Favorite) HasTag(tag string) bool {
+	return containsString(f.Tags, tag)
+}
+
+// InCollection 检查收藏是否属于指定收藏集
+func (f Favorite) InCollection(collection string) bool {
+	return containsString(f.Collections, collection)
 }
 
 // HistoryEntry 历史记录条目
@@ -177,9 +203,9 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("language 必须是 zh 或 en")
 	}
 
-	validFormats := map[string]bool{"markdown": true, "json": true}
-	if !validFormats[c.Export.DefaultFormat] {
-		return fmt.Errorf("export.default_format 必须是 markdown 或 json")
+	// 具体格式是否已注册由 service 层对照导出注册表检查
+	if c.Export.DefaultFormat == "" {
+		return fmt.Errorf("export.default_format 不能为空")
 	}
 
 	return nil
@@ -274,6 +300,115 @@ func (u *UserData) IsFavorite(commandName string) bool {
 	return false
 }
 
+// FindFavorite 查找收藏，不存在时返回 nil
+func (u *UserData) FindFavorite(commandName string) *Favorite {
+	for i := range u.Favorites {
+		if u.Favorites[i].CommandName == commandName {
+			return &u.Favorites[i]
+		}
+	}
+	return nil
+}
+
+// SetFavoriteNote 修改收藏备注，收藏不存在时返回 false
+func (u *UserData) SetFavoriteNote(commandName, note string) bool {
+	fav := u.FindFavorite(commandName)
+	if fav == nil {
+		return false
+	}
+	fav.Note = note
+	return true
+}
+
+// TagFavorite 给收藏添加标签和收藏集，已有的不重复添加；收藏不存在时返回 false
+func (u *UserData) TagFavorite(commandName string, tags, collections []string) bool {
+	fav := u.FindFavorite(commandName)
+	if fav == nil {
+		return false
+	}
+	fav.Tags = appendUnique(fav.Tags, tags)
+	fav.Collections = appendUnique(fav.Collections, collections)
+	return true
+}
+
+// UntagFavorite 移除收藏的标签和收藏集；收藏不存在时返回 false
+func (u *UserData) UntagFavorite(commandName string, tags, collections []string) bool {
+	fav := u.FindFavorite(commandName)
+	if fav == nil {
+		return false
+	}
+	fav.Tags = removeStrings(fav.Tags, tags)
+	fav.Collections = removeStrings(fav.Collections, collections)
+	return true
+}
+
+// FilterFavorites 按标签和收藏集筛选收藏，参数为空表示不限
+func (u *UserData) FilterFavorites(tag, collection string) []Favorite {
+	var result []Favorite
+	for _, fav := range u.Favorites {
+		if tag != "" && !fav.HasTag(tag) {
+			continue
+		}
+		if collection != "" && !fav.InCollection(collection) {
+			continue
+		}
+		result = append(result, fav)
+	}
+	return result
+}
+
+// FavoriteTags 所有收藏用到的标签（已排序）
+func (u *UserData) FavoriteTags() []string {
+	var tags []string
+	for _, fav := range u.Favorites {
+		tags = appendUnique(tags, fav.Tags)
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// FavoriteCollections 所有收藏集名称（已排序）
+func (u *UserData) FavoriteCollections() []string {
+	var collections []string
+	for _, fav := range u.Favorites {
+		collections = appendUnique(collections, fav.Collections)
+	}
+	sort.Strings(collections)
+	return collections
+}
+
+// containsString 检查切片中是否包含指定字符串
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// appendUnique 追加切片中还没有的非空字符串
+func appendUnique(list, values []string) []string {
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v != "" && !containsString(list, v) {
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
+// removeStrings 从切片中移除指定字符串
+func removeStrings(list, values []string) []string {
+	var result []string
+	for _, item := range list {
+		if !containsString(values, item) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
 // AddHistory 添加历史记录
 func (u *UserData) AddHistory(commandName, category string) {
 	// 移除旧的相同记录
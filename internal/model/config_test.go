@@ -26,6 +26,20 @@ func TestDefaultConfig(t *testing.T) {
 	}
 }
 
+func TestExportConfigOutputPath(t *testing.T) {
+	now := time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)
+
+	cfg := ExportConfig{OutputDir: "out", IncludeDate: true}
+	if got := cfg.OutputPath("commands", "md", now); got != filepath.Join("out", "commands-2026-10-19.md") {
+		t.Errorf("OutputPath() = %s", got)
+	}
+
+	cfg.IncludeDate = false
+	if got := cfg.OutputPath("commands", "json", now); got != filepath.Join("out", "commands.json") {
+		t.Errorf("OutputPath() = %s", got)
+	}
+}
+
 func TestConfigValidation(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -58,12 +72,12 @@ func TestConfigValidation(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name: "invalid export format",
+			name: "empty export format",
 			config: &Config{
 				Language: "zh",
 				PageSize: 10,
 				Search:   SearchConfig{MaxResults: 10, CacheSize: 10},
-				Export:   ExportConfig{DefaultFormat: "xml"},
+				Export:   ExportConfig{DefaultFormat: ""},
 			},
 			wantErr: true,
 		},
@@ -192,6 +206,47 @@ func TestUserDataRemoveFavorite(t *testing.T) {
 	}
 }
 
+func TestUserDataTagFavorite(t *testing.T) {
+	userData := NewUserData()
+
+	userData.AddFavorite("kubectl logs", "容器编排/Kubernetes", "")
+	userData.AddFavorite("psql", "数据库/PostgreSQL", "")
+
+	if userData.TagFavorite("cd", []string{"x"}, nil) {
+		t.Error("Tagging a non-favorite should return false")
+	}
+
+	userData.TagFavorite("kubectl logs", []string{"k8s", " debug ", "k8s", ""}, []string{"oncall"})
+	userData.TagFavorite("psql", []string{"debug"}, []string{"db-migration", "oncall"})
+
+	fav := userData.FindFavorite("kubectl logs")
+	if fav == nil || len(fav.Tags) != 2 || !fav.HasTag("debug") {
+		t.Fatalf("Unexpected tags: %+v", fav)
+	}
+
+	if got := userData.FilterFavorites("debug", ""); len(got) != 2 {
+		t.Errorf("Expected 2 favorites tagged debug, got %d", len(got))
+	}
+	if got := userData.FilterFavorites("k8s", "oncall"); len(got) != 1 || got[0].CommandName != "kubectl logs" {
+		t.Errorf("Unexpected filter result: %+v", got)
+	}
+	if got := userData.FavoriteCollections(); len(got) != 2 || got[0] != "db-migration" {
+		t.Errorf("Unexpected collections: %v", got)
+	}
+
+	userData.UntagFavorite("kubectl logs", []string{"k8s"}, []string{"oncall"})
+	if fav.HasTag("k8s") || fav.InCollection("oncall") {
+		t.Errorf("Tag or collection not removed: %+v", fav)
+	}
+	if got := userData.FavoriteTags(); len(got) != 1 || got[0] != "debug" {
+		t.Errorf("Unexpected tags: %v", got)
+	}
+
+	if !userData.SetFavoriteNote("psql", "连接生产库前先确认") || userData.FindFavorite("psql").Note == "" {
+		t.Error("SetFavoriteNote failed")
+	}
+}
+
 func TestUserDataAddHistory(t *testing.T) {
 	userData := NewUserData()
 
@@ -0,0 +1,98 @@
+package model
+
+import (
+	"strings"
+	"unicode"
+)
+
+// skippablePrefixes 命令行开头可以忽略的前缀，如 sudo
+var skippablePrefixes = map[string]bool{
+	"sudo": true,
+}
+
+// MatchesLine 检查命令行是否以该命令开头（忽略 sudo 前缀，命令名不区分大小写）
+func (c *Command) MatchesLine(line string) bool {
+	_, ok := c.nameEnd(line)
+	return ok
+}
+
+// Preview 把命令行改写为预演形式：在命令名之后插入 dry_run 选项
+//
+// 命令行已包含预演选项时原样返回。
+func (c *Command) Preview(line string) (string, error) {
+	if c.DryRun == nil {
+		return "", ErrNoDryRun{Command: c.Name}
+	}
+
+	line = strings.TrimSpace(line)
+	end, ok := c.nameEnd(line)
+	if !ok {
+		return "", ErrCommandLineMismatch{Command: c.Name, Line: line}
+	}
+
+	if hasFlags(strings.Fields(line[end:]), strings.Fields(c.DryRun.Flag)) {
+		return line, nil
+	}
+	return line[:end] + " " + c.DryRun.Flag + line[end:], nil
+}
+
+// nameEnd 返回命令行中命令名结束的位置
+func (c *Command) nameEnd(line string) (int, bool) {
+	nameTokens := strings.Fields(c.Name)
+	if len(nameTokens) == 0 {
+		return 0, false
+	}
+
+	pos := 0
+	matched := 0
+	for matched < len(nameTokens) {
+		token, end := nextToken(line, pos)
+		if token == "" {
+			return 0, false
+		}
+		pos = end
+		if matched == 0 && skippablePrefixes[token] {
+			continue
+		}
+		if !strings.EqualFold(token, nameTokens[matched]) {
+			return 0, false
+		}
+		matched++
+	}
+	return pos, true
+}
+
+// nextToken 返回 line 中从 pos 开始的下一个以空白分隔的词及其结束位置
+func nextToken(line string, pos int) (string, int) {
+	start := strings.IndexFunc(line[pos:], func(r rune) bool { return !unicode.IsSpace(r) })
+	if start < 0 {
+		return "", len(line)
+	}
+	start += pos
+
+	end := strings.IndexFunc(line[start:], unicode.IsSpace)
+	if end < 0 {
+		end = len(line)
+	} else {
+		end += start
+	}
+	return line[start:end], end
+}
+
+// hasFlags 检查参数中是否已包含所有选项；--flag=value 形式只比较选项名
+func hasFlags(args, flags []string) bool {
+	for _, flag := range flags {
+		name := strings.SplitN(flag, "=", 2)[0]
+		found := false
+		for _, arg := range args {
+			if arg == flag || arg == name || strings.HasPrefix(arg, name+"=") {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,53 @@
+package model
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCommand_Preview(t *testing.T) {
+	kubectl := &Command{Name: "kubectl delete", DryRun: &DryRun{Flag: "--dry-run=client"}}
+
+	tests := []struct {
+		name string
+		line string
+		want string
+	}{
+		{"insert after name", "kubectl delete -f deploy.yaml", "kubectl delete --dry-run=client -f deploy.yaml"},
+		{"sudo prefix", "  sudo kubectl   delete pod api-0 ", "sudo kubectl   delete --dry-run=client pod api-0"},
+		{"name only", "kubectl delete", "kubectl delete --dry-run=client"},
+		{"already dry run", "kubectl delete pod api-0 --dry-run=server", "kubectl delete pod api-0 --dry-run=server"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := kubectl.Preview(tt.line)
+			if err != nil {
+				t.Fatalf("Preview() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Preview() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := kubectl.Preview("kubectl get pods"); !errors.As(err, &ErrCommandLineMismatch{}) {
+		t.Errorf("Expected ErrCommandLineMismatch, got %v", err)
+	}
+
+	rm := &Command{Name: "rm"}
+	if _, err := rm.Preview("rm -rf /tmp/x"); !errors.As(err, &ErrNoDryRun{}) {
+		t.Errorf("Expected ErrNoDryRun, got %v", err)
+	}
+}
+
+func TestCommand_MatchesLine(t *testing.T) {
+	cmd := &Command{Name: "redis-cli FLUSHDB"}
+
+	if !cmd.MatchesLine("redis-cli flushdb async") {
+		t.Error("Expected case-insensitive match")
+	}
+	if cmd.MatchesLine("redis-cli FLUSHALL") || cmd.MatchesLine("redis-cli") {
+		t.Error("Unexpected match")
+	}
+}
@@ -0,0 +1,97 @@
+package model
+
+import "strings"
+
+// Equivalence 命令的意图和语义选项，意图相同的命令互为等价写法（如 apt install 与 yum install）
+type Equivalence struct {
+	Intent string            `yaml:"intent" json:"intent"`                   // 意图，如 install package
+	Flags  map[string]string `yaml:"flags,omitempty" json:"flags,omitempty"` // 语义选项到本命令选项的映射，如 assume_yes: -y
+}
+
+// Translation 命令行转换结果
+type Translation struct {
+	Command string   `json:"command"`           // 目标命令名称
+	Line    string   `json:"line"`              // 转换后的命令行
+	Dropped []string `json:"dropped,omitempty"` // 目标命令没有对应写法、已去掉的选项
+	Kept    []string `json:"kept,omitempty"`    // 未声明映射、原样保留的选项
+}
+
+// Intent 返回命令的意图，未声明时为空
+func (c *Command) Intent() string {
+	if c.Equivalents == nil {
+		return ""
+	}
+	return c.Equivalents.Intent
+}
+
+// IsEquivalentTo 检查两个命令是否声明了相同的意图
+func (c *Command) IsEquivalentTo(other *Command) bool {
+	return c.Intent() != "" && c != other && c.Name != other.Name &&
+		strings.EqualFold(c.Intent(), other.Intent())
+}
+
+// TranslateLine 把以该命令开头的命令行改写为等价的目标命令
+//
+// 是否加 sudo 以目标命令的用法为准；参数原样保留，选项按双方声明的语义映射替换，
+// 目标命令没有对应写法的选项会被去掉。
+func (c *Command) TranslateLine(line string, target *Command) (Translation, error) {
+	if !c.IsEquivalentTo(target) {
+		return Translation{}, ErrNoEquivalent{Command: c.Name, Target: target.Name}
+	}
+
+	line = strings.TrimSpace(line)
+	end, ok := c.nameEnd(line)
+	if !ok {
+		return Translation{}, ErrCommandLineMismatch{Command: c.Name, Line: line}
+	}
+
+	parts := make([]string, 0, 8)
+	if target.usesSudo() {
+		parts = append(parts, "sudo")
+	}
+	parts = append(parts, target.Name)
+
+	result := Translation{Command: target.Name}
+	for _, arg := range strings.Fields(line[end:]) {
+		if !strings.HasPrefix(arg, "-") {
+			parts = append(parts, arg)
+			continue
+		}
+
+		key, value := c.semanticFlag(arg)
+		if key == "" {
+			result.Kept = append(result.Kept, arg)
+			parts = append(parts, arg)
+			continue
+		}
+
+		flag, ok := target.Equivalents.Flags[key]
+		if !ok {
+			result.Dropped = append(result.Dropped, arg)
+			continue
+		}
+		if value != "" {
+			flag += "=" + value
+		}
+		parts = append(parts, flag)
+	}
+
+	result.Line = strings.Join(parts, " ")
+	return result, nil
+}
+
+// semanticFlag 查找选项对应的语义名称，--flag=value 形式同时返回值
+func (c *Command) semanticFlag(arg string) (string, string) {
+	name, value, _ := strings.Cut(arg, "=")
+	for key, flag := range c.Equivalents.Flags {
+		if flag == name {
+			return key, value
+		}
+	}
+	return "", ""
+}
+
+// usesSudo 命令的用法是否以 sudo 开头
+func (c *Command) usesSudo() bool {
+	return len(c.Usage) > 0 && strings.HasPrefix(c.Usage[0], "sudo ")
+}
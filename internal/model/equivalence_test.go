@@ -0,0 +1,54 @@
+package model
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCommand_TranslateLine(t *testing.T) {
+	aptRemove := &Command{
+		Name: "apt remove",
+		Equivalents: &Equivalence{
+			Intent: "remove package",
+			Flags:  map[string]string{"assume_yes": "-y", "purge": "--purge"},
+		},
+	}
+	yumRemove := &Command{
+		Name:  "yum remove",
+		Usage: []string{"sudo yum remove <package>"},
+		Equivalents: &Equivalence{
+			Intent: "Remove Package",
+			Flags:  map[string]string{"assume_yes": "-y"},
+		},
+	}
+
+	got, err := aptRemove.TranslateLine("apt remove -y --purge --no-auto nginx", yumRemove)
+	if err != nil {
+		t.Fatalf("TranslateLine() error = %v", err)
+	}
+	if got.Line != "sudo yum remove -y --no-auto nginx" {
+		t.Errorf("Line = %q", got.Line)
+	}
+	if len(got.Dropped) != 1 || got.Dropped[0] != "--purge" {
+		t.Errorf("Dropped = %v", got.Dropped)
+	}
+	if len(got.Kept) != 1 || got.Kept[0] != "--no-auto" {
+		t.Errorf("Kept = %v", got.Kept)
+	}
+
+	brewRemove := &Command{Name: "brew uninstall", Usage: []string{"brew uninstall <formula>"}, Equivalents: &Equivalence{Intent: "remove package"}}
+	if got, _ := aptRemove.TranslateLine("sudo apt remove nginx", brewRemove); got.Line != "brew uninstall nginx" {
+		t.Errorf("Expected sudo to follow target usage, got %q", got.Line)
+	}
+
+	aptInstall := &Command{Name: "apt install", Equivalents: &Equivalence{Intent: "install package"}}
+	if _, err := aptRemove.TranslateLine("apt remove nginx", aptInstall); !errors.As(err, &ErrNoEquivalent{}) {
+		t.Errorf("Expected ErrNoEquivalent, got %v", err)
+	}
+	if _, err := aptRemove.TranslateLine("apt install nginx", yumRemove); !errors.As(err, &ErrCommandLineMismatch{}) {
+		t.Errorf("Expected ErrCommandLineMismatch, got %v", err)
+	}
+	if aptRemove.IsEquivalentTo(aptRemove) {
+		t.Error("Command should not be equivalent to itself")
+	}
+}
@@ -58,3 +58,76 @@ type ErrDataLoadFailed struct {
 func (e ErrDataLoadFailed) Error() string {
 	return fmt.Sprintf("failed to load data file '%s': %v", e.File, e.Err)
 }
+
+// ErrInvalidRunbook 运行手册定义错误
+type ErrInvalidRunbook struct {
+	ID     string
+	Reason string
+}
+
+func (e ErrInvalidRunbook) Error() string {
+	return fmt.Sprintf("invalid runbook '%s': %s", e.ID, e.Reason)
+}
+
+// ErrRunbookNotFound 运行手册未找到错误
+type ErrRunbookNotFound struct {
+	ID string
+}
+
+func (e ErrRunbookNotFound) Error() string {
+	return fmt.Sprintf("runbook not found: %s", e.ID)
+}
+
+// ErrNoDryRun 命令没有预演方式
+type ErrNoDryRun struct {
+	Command string
+}
+
+func (e ErrNoDryRun) Error() string {
+	return fmt.Sprintf("command '%s' has no dry-run mode", e.Command)
+}
+
+// ErrCommandLineMismatch 命令行与命令不匹配
+type ErrCommandLineMismatch struct {
+	Command string
+	Line    string
+}
+
+func (e ErrCommandLineMismatch) Error() string {
+	return fmt.Sprintf("command line does not start with '%s': %s", e.Command, e.Line)
+}
+
+// ErrInvalidPlatformOverride 平台覆盖定义错误
+type ErrInvalidPlatformOverride struct {
+	Command  string
+	Platform string
+	Reason   string
+}
+
+func (e ErrInvalidPlatformOverride) Error() string {
+	return fmt.Sprintf("invalid platform override '%s' in command '%s': %s", e.Platform, e.Command, e.Reason)
+}
+
+// ErrNoEquivalent 没有等价命令
+type ErrNoEquivalent struct {
+	Command string
+	Target  string
+}
+
+func (e ErrNoEquivalent) Error() string {
+	if e.Target == "" {
+		return fmt.Sprintf("no equivalent command for '%s'", e.Command)
+	}
+	return fmt.Sprintf("command '%s' is not equivalent to '%s'", e.Command, e.Target)
+}
+
+// ErrUnknownReference 引用了数据集中不存在的命令
+type ErrUnknownReference struct {
+	Command string // 发出引用的命令或运行手册
+	Field   string // 引用所在的字段
+	Target  string // 被引用的命令名称
+}
+
+func (e ErrUnknownReference) Error() string {
+	return fmt.Sprintf("'%s' references unknown command '%s' in %s", e.Command, e.Target, e.Field)
+}
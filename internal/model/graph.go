@@ -0,0 +1,172 @@
+package model
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// RelationKind 命令之间的关系类型
+type RelationKind string
+
+const (
+	RelationRelated     RelationKind = "related"     // 相关命令（related_commands）
+	RelationEquivalent  RelationKind = "equivalent"  // 等价命令（equivalents 意图相同）
+	RelationAlternative RelationKind = "alternative" // 更安全的替代命令（safer_alternatives）
+)
+
+// Label 关系的显示名称
+func (k RelationKind) Label() string {
+	switch k {
+	case RelationRelated:
+		return "相关"
+	case RelationEquivalent:
+		return "等价"
+	case RelationAlternative:
+		return "更安全"
+	default:
+		return string(k)
+	}
+}
+
+// Relation 两个命令之间的一条关系
+type Relation struct {
+	From string       `json:"from"`
+	To   string       `json:"to"`
+	Kind RelationKind `json:"kind"`
+}
+
+// Other 返回关系中另一端的命令名称
+func (r Relation) Other(name string) string {
+	if r.From == name {
+		return r.To
+	}
+	return r.From
+}
+
+// Relations 命令自身声明的关系：相关命令和更安全的替代命令
+//
+// 等价关系需要对照整个数据集，由服务层补充。
+func (c *Command) Relations() []Relation {
+	var relations []Relation
+	for _, name := range c.RelatedCommands {
+		if name != c.Name {
+			relations = append(relations, Relation{From: c.Name, To: name, Kind: RelationRelated})
+		}
+	}
+	for _, alt := range c.SaferAlternatives {
+		if alt.Command != "" && alt.Command != c.Name {
+			relations = append(relations, Relation{From: c.Name, To: alt.Command, Kind: RelationAlternative})
+		}
+	}
+	return relations
+}
+
+// CommandGraph 以某个命令为中心的关系图
+type CommandGraph struct {
+	Root  string     `json:"root"`  // 中心命令
+	Nodes []string   `json:"nodes"` // 图中的命令，按与中心的距离排列，中心在最前
+	Edges []Relation `json:"edges"` // 图中的关系
+}
+
+// EdgesOf 返回与指定命令相连的关系
+func (g *CommandGraph) EdgesOf(name string) []Relation {
+	var edges []Relation
+	for _, e := range g.Edges {
+		if e.From == name || e.To == name {
+			edges = append(edges, e)
+		}
+	}
+	return edges
+}
+
+// SortEdges 按起点、终点和关系类型排序，保证输出稳定
+func (g *CommandGraph) SortEdges() {
+	sort.Slice(g.Edges, func(i, j int) bool {
+		a, b := g.Edges[i], g.Edges[j]
+		if a.From != b.From {
+			return a.From < b.From
+		}
+		if a.To != b.To {
+			return a.To < b.To
+		}
+		return a.Kind < b.Kind
+	})
+}
+
+// WriteDOT 以 Graphviz DOT 格式写出关系图
+func (g *CommandGraph) WriteDOT(w io.Writer) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "digraph %s {\n", dotQuote(g.Root))
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box, fontname=\"monospace\"];\n")
+	for _, node := range g.Nodes {
+		if node == g.Root {
+			fmt.Fprintf(&b, "  %s [style=bold];\n", dotQuote(node))
+		} else {
+			fmt.Fprintf(&b, "  %s;\n", dotQuote(node))
+		}
+	}
+	for _, e := range g.Edges {
+		attrs := fmt.Sprintf("label=%s", dotQuote(e.Kind.Label()))
+		switch e.Kind {
+		case RelationEquivalent:
+			attrs += ", style=dashed, dir=both"
+		case RelationAlternative:
+			attrs += ", color=darkgreen, penwidth=2"
+		}
+		fmt.Fprintf(&b, "  %s -> %s [%s];\n", dotQuote(e.From), dotQuote(e.To), attrs)
+	}
+	b.WriteString("}\n")
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("failed to write graph: %w", err)
+	}
+	return nil
+}
+
+// WriteMermaid 以 Mermaid flowchart 格式写出关系图
+func (g *CommandGraph) WriteMermaid(w io.Writer) error {
+	var b strings.Builder
+
+	ids := make(map[string]string, len(g.Nodes))
+	b.WriteString("graph LR\n")
+	for i, node := range g.Nodes {
+		ids[node] = fmt.Sprintf("n%d", i)
+		fmt.Fprintf(&b, "  %s[\"%s\"]\n", ids[node], mermaidEscape(node))
+	}
+	for _, e := range g.Edges {
+		from, to := ids[e.From], ids[e.To]
+		if from == "" || to == "" {
+			continue
+		}
+		switch e.Kind {
+		case RelationEquivalent:
+			fmt.Fprintf(&b, "  %s -.-|%s| %s\n", from, e.Kind.Label(), to)
+		case RelationAlternative:
+			fmt.Fprintf(&b, "  %s ==>|%s| %s\n", from, e.Kind.Label(), to)
+		default:
+			fmt.Fprintf(&b, "  %s --> %s\n", from, to)
+		}
+	}
+	if root, ok := ids[g.Root]; ok {
+		fmt.Fprintf(&b, "  style %s stroke-width:3px\n", root)
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("failed to write graph: %w", err)
+	}
+	return nil
+}
+
+// dotQuote 把字符串转为 DOT 的带引号标识符
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// mermaidEscape 转义 Mermaid 节点文本中的引号
+func mermaidEscape(s string) string {
+	return strings.ReplaceAll(s, `"`, "#quot;")
+}
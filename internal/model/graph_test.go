@@ -0,0 +1,80 @@
+package model
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestCommand_Relations(t *testing.T) {
+	cmd := &Command{
+		Name:              "dropdb",
+		RelatedCommands:   []string{"createdb", "dropdb"},
+		SaferAlternatives: []SaferAlternative{{Command: "pg_dump", Description: "先备份"}, {Flags: "-i", Description: "确认"}},
+	}
+
+	relations := cmd.Relations()
+	if len(relations) != 2 {
+		t.Fatalf("Expected 2 relations, got %+v", relations)
+	}
+	if relations[0] != (Relation{From: "dropdb", To: "createdb", Kind: RelationRelated}) {
+		t.Errorf("Unexpected related relation: %+v", relations[0])
+	}
+	if relations[1].Kind != RelationAlternative || relations[1].Other("dropdb") != "pg_dump" {
+		t.Errorf("Unexpected alternative relation: %+v", relations[1])
+	}
+}
+
+func newTestGraph() *CommandGraph {
+	g := &CommandGraph{
+		Root:  "apt install",
+		Nodes: []string{"apt install", "yum install", `say "hi"`},
+		Edges: []Relation{
+			{From: "yum install", To: "apt install", Kind: RelationEquivalent},
+			{From: "apt install", To: `say "hi"`, Kind: RelationRelated},
+		},
+	}
+	g.SortEdges()
+	return g
+}
+
+func TestCommandGraph_WriteDOT(t *testing.T) {
+	var buf bytes.Buffer
+	if err := newTestGraph().WriteDOT(&buf); err != nil {
+		t.Fatalf("WriteDOT() error = %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		`digraph "apt install" {`,
+		`"apt install" [style=bold];`,
+		`"apt install" -> "say \"hi\"" [label="相关"];`,
+		`"yum install" -> "apt install" [label="等价", style=dashed, dir=both];`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("DOT output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Index(out, `"apt install" -> "say`) > strings.Index(out, `"yum install" ->`) {
+		t.Error("Edges should be sorted")
+	}
+}
+
+func TestCommandGraph_WriteMermaid(t *testing.T) {
+	var buf bytes.Buffer
+	if err := newTestGraph().WriteMermaid(&buf); err != nil {
+		t.Fatalf("WriteMermaid() error = %v", err)
+	}
+
+	want := `graph LR
+  n0["apt install"]
+  n1["yum install"]
+  n2["say #quot;hi#quot;"]
+  n0 --> n2
+  n1 -.-|等价| n0
+  style n0 stroke-width:3px
+`
+	if buf.String() != want {
+		t.Errorf("WriteMermaid() =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
@@ -0,0 +1,83 @@
+package model
+
+import "sort"
+
+// PlatformOverride 某个平台上与通用写法不同的用法、选项和示例
+//
+// 用法和示例非空时整体替换通用内容；选项按 flag 合并，同名选项以覆盖为准；
+// 注意事项追加在通用注意事项之后。
+type PlatformOverride struct {
+	Usage    []string  `yaml:"usage,omitempty" json:"usage,omitempty"`       // 该平台的用法
+	Options  []Option  `yaml:"options,omitempty" json:"options,omitempty"`   // 该平台不同或特有的选项
+	Examples []Example `yaml:"examples,omitempty" json:"examples,omitempty"` // 该平台的示例
+	Notes    []string  `yaml:"notes,omitempty" json:"notes,omitempty"`       // 该平台的注意事项
+}
+
+// IsEmpty 检查覆盖是否没有任何内容
+func (o PlatformOverride) IsEmpty() bool {
+	return len(o.Usage) == 0 && len(o.Options) == 0 && len(o.Examples) == 0 && len(o.Notes) == 0
+}
+
+// Merge 合并同一平台的两份覆盖（如数据中同时写了 macos 和 darwin）
+func (o PlatformOverride) Merge(other PlatformOverride) PlatformOverride {
+	return PlatformOverride{
+		Usage:    append(append([]string(nil), o.Usage...), other.Usage...),
+		Options:  mergeOptions(o.Options, other.Options),
+		Examples: append(append([]Example(nil), o.Examples...), other.Examples...),
+		Notes:    append(append([]string(nil), o.Notes...), other.Notes...),
+	}
+}
+
+// ForPlatform 返回应用了指定平台覆盖的命令副本；该平台没有覆盖时返回命令本身
+func (c *Command) ForPlatform(platform string) *Command {
+	override, ok := c.PlatformOverrides[platform]
+	if !ok {
+		return c
+	}
+
+	resolved := *c
+	if len(override.Usage) > 0 {
+		resolved.Usage = override.Usage
+	}
+	resolved.Options = mergeOptions(c.Options, override.Options)
+	if len(override.Examples) > 0 {
+		resolved.Examples = override.Examples
+	}
+	if len(override.Notes) > 0 {
+		resolved.Notes = append(append([]string(nil), c.Notes...), override.Notes...)
+	}
+	return &resolved
+}
+
+// OverridePlatforms 返回定义了覆盖的平台，按名称排序
+func (c *Command) OverridePlatforms() []string {
+	platforms := make([]string, 0, len(c.PlatformOverrides))
+	for platform := range c.PlatformOverrides {
+		platforms = append(platforms, platform)
+	}
+	sort.Strings(platforms)
+	return platforms
+}
+
+// mergeOptions 按 flag 合并选项：同名选项替换，新选项追加在后
+func mergeOptions(base, override []Option) []Option {
+	if len(override) == 0 {
+		return base
+	}
+
+	merged := append([]Option(nil), base...)
+	for _, opt := range override {
+		replaced := false
+		for i := range merged {
+			if merged[i].Flag == opt.Flag {
+				merged[i] = opt
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			merged = append(merged, opt)
+		}
+	}
+	return merged
+}
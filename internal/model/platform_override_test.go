@@ -0,0 +1,75 @@
+package model
+
+import "testing"
+
+func newSedCommand() *Command {
+	return &Command{
+		Name:     "sed",
+		Usage:    []string{"sed -i 's/a/b/' file"},
+		Options:  []Option{{Flag: "-i", Description: "就地编辑"}, {Flag: "-E", Description: "扩展正则"}},
+		Examples: []Example{{Command: "sed -i 's/foo/bar/g' a.txt", Description: "替换"}},
+		Notes:    []string{"通用说明"},
+		PlatformOverrides: map[string]PlatformOverride{
+			"darwin": {
+				Usage:   []string{"sed -i '' 's/a/b/' file"},
+				Options: []Option{{Flag: "-i", Description: "就地编辑，必须带备份后缀参数"}},
+				Notes:   []string{"BSD sed"},
+			},
+		},
+	}
+}
+
+func TestCommand_ForPlatform(t *testing.T) {
+	cmd := newSedCommand()
+
+	if got := cmd.ForPlatform("linux"); got != cmd {
+		t.Error("Expected command itself when platform has no override")
+	}
+
+	mac := cmd.ForPlatform("darwin")
+	if mac.Usage[0] != "sed -i '' 's/a/b/' file" {
+		t.Errorf("Expected darwin usage, got %v", mac.Usage)
+	}
+	if len(mac.Options) != 2 || mac.Options[0].Description != "就地编辑，必须带备份后缀参数" || mac.Options[1].Flag != "-E" {
+		t.Errorf("Unexpected merged options: %+v", mac.Options)
+	}
+	if len(mac.Examples) != 1 || mac.Examples[0].Command != "sed -i 's/foo/bar/g' a.txt" {
+		t.Errorf("Expected base examples to be kept, got %+v", mac.Examples)
+	}
+	if len(mac.Notes) != 2 || mac.Notes[1] != "BSD sed" {
+		t.Errorf("Unexpected notes: %v", mac.Notes)
+	}
+
+	// 原命令不应被修改
+	if cmd.Usage[0] != "sed -i 's/a/b/' file" || cmd.Options[0].Description != "就地编辑" || len(cmd.Notes) != 1 {
+		t.Error("ForPlatform modified the original command")
+	}
+}
+
+func TestPlatformOverride_Merge(t *testing.T) {
+	a := PlatformOverride{Usage: []string{"a"}, Options: []Option{{Flag: "-i", Description: "old"}}}
+	b := PlatformOverride{Usage: []string{"b"}, Options: []Option{{Flag: "-i", Description: "new"}}}
+
+	merged := a.Merge(b)
+	if len(merged.Usage) != 2 || len(merged.Options) != 1 || merged.Options[0].Description != "new" {
+		t.Errorf("Unexpected merge result: %+v", merged)
+	}
+	if merged.IsEmpty() || !(PlatformOverride{}).IsEmpty() {
+		t.Error("Unexpected IsEmpty result")
+	}
+}
+
+func TestCommand_ValidatePlatformOverride(t *testing.T) {
+	cmd := newSedCommand()
+	cmd.Category = "test"
+	cmd.Description = "test"
+	cmd.Platforms = []string{"linux", "darwin"}
+	if err := cmd.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	cmd.PlatformOverrides["windows"] = PlatformOverride{}
+	if err := cmd.Validate(); err == nil {
+		t.Error("Expected error for empty override")
+	}
+}
@@ -0,0 +1,149 @@
+package model
+
+import "fmt"
+
+// Runbook 运行手册：由数据集中的命令组成的有序排障/操作步骤
+type Runbook struct {
+	ID          string         `yaml:"id" json:"id"`                                     // 运行手册ID
+	Title       string         `yaml:"title" json:"title"`                               // 标题
+	Description string         `yaml:"description" json:"description"`                   // 适用场景说明
+	Tags        []string       `yaml:"tags,omitempty" json:"tags,omitempty"`             // 标签
+	Params      []RunbookParam `yaml:"params,omitempty" json:"params,omitempty"`         // 参数，步骤中用 {{参数名}} 引用
+	Steps       []RunbookStep  `yaml:"steps" json:"steps"`                               // 步骤
+	References  []string       `yaml:"references,omitempty" json:"references,omitempty"` // 参考链接
+}
+
+// RunbookParam 运行手册参数
+type RunbookParam struct {
+	Name        string `yaml:"name" json:"name"`                           // 参数名
+	Description string `yaml:"description" json:"description"`             // 参数说明
+	Default     string `yaml:"default,omitempty" json:"default,omitempty"` // 默认值
+}
+
+// RunbookStep 运行手册中的一个步骤
+type RunbookStep struct {
+	ID       string          `yaml:"id,omitempty" json:"id,omitempty"`             // 步骤ID（分支跳转时引用）
+	Title    string          `yaml:"title" json:"title"`                           // 步骤标题
+	Command  string          `yaml:"command" json:"command"`                       // 引用的命令名称
+	Run      string          `yaml:"run" json:"run"`                               // 填好参数的具体命令行
+	Expected string          `yaml:"expected,omitempty" json:"expected,omitempty"` // 预期输出
+	Branches []RunbookBranch `yaml:"branches,omitempty" json:"branches,omitempty"` // 根据结果的分支
+	Notes    []string        `yaml:"notes,omitempty" json:"notes,omitempty"`       // 注意事项
+}
+
+// RunbookBranch 步骤结果的分支说明
+type RunbookBranch struct {
+	When string `yaml:"when" json:"when"`                     // 条件
+	Then string `yaml:"then" json:"then"`                     // 处理方式
+	Goto string `yaml:"goto,omitempty" json:"goto,omitempty"` // 跳转到的步骤ID（可选）
+}
+
+// RunbookList 运行手册文件的包装类型
+type RunbookList struct {
+	Runbooks []*Runbook `yaml:"runbooks" json:"runbooks"` // 运行手册列表
+}
+
+// Validate 验证运行手册
+func (r *Runbook) Validate() error {
+	if r.ID == "" {
+		return ErrMissingField{Field: "id"}
+	}
+	if r.Title == "" {
+		return ErrMissingField{Field: "title"}
+	}
+	if len(r.Steps) == 0 {
+		return ErrMissingField{Field: "steps"}
+	}
+
+	declared := make(map[string]bool)
+	for _, p := range r.Params {
+		if p.Name == "" {
+			return ErrInvalidRunbook{ID: r.ID, Reason: "parameter without name"}
+		}
+		declared[p.Name] = true
+	}
+
+	stepIDs := make(map[string]bool)
+	for _, step := range r.Steps {
+		if step.ID == "" {
+			continue
+		}
+		if stepIDs[step.ID] {
+			return ErrInvalidRunbook{ID: r.ID, Reason: fmt.Sprintf("duplicate step id '%s'", step.ID)}
+		}
+		stepIDs[step.ID] = true
+	}
+
+	for i, step := range r.Steps {
+		if step.Title == "" || step.Command == "" || step.Run == "" {
+			return ErrInvalidRunbook{ID: r.ID, Reason: fmt.Sprintf("step %d requires title, command and run", i+1)}
+		}
+		for _, name := range placeholders(step.Run) {
+			if !declared[name] {
+				return ErrInvalidRunbook{ID: r.ID, Reason: fmt.Sprintf("step %d uses undeclared parameter '%s'", i+1, name)}
+			}
+		}
+		for _, b := range step.Branches {
+			if b.Goto != "" && !stepIDs[b.Goto] {
+				return ErrInvalidRunbook{ID: r.ID, Reason: fmt.Sprintf("step %d branches to unknown step '%s'", i+1, b.Goto)}
+			}
+		}
+	}
+
+	return nil
+}
+
+// StepIndex 根据步骤ID查找步骤下标，不存在时返回 -1
+func (r *Runbook) StepIndex(id string) int {
+	for i, step := range r.Steps {
+		if step.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// ParamValues 合并参数默认值和指定值
+func (r *Runbook) ParamValues(values map[string]string) map[string]string {
+	merged := make(map[string]string)
+	for _, p := range r.Params {
+		if p.Default != "" {
+			merged[p.Name] = p.Default
+		}
+	}
+	for name, v := range values {
+		merged[name] = v
+	}
+	return merged
+}
+
+// MissingParams 既没有默认值也没有指定值的参数
+func (r *Runbook) MissingParams(values map[string]string) []string {
+	merged := r.ParamValues(values)
+	var missing []string
+	for _, p := range r.Params {
+		if _, ok := merged[p.Name]; !ok {
+			missing = append(missing, p.Name)
+		}
+	}
+	return missing
+}
+
+// RenderStep 用参数值填充步骤的命令行，参数值按 shell 规则引用，缺少值的参数保留占位符
+func (r *Runbook) RenderStep(i int, values map[string]string) string {
+	quoted := make(map[string]string)
+	for name, v := range r.ParamValues(values) {
+		quoted[name] = ShellQuote(v)
+	}
+	return fillPlaceholders(r.Steps[i].Run, quoted)
+}
+
+// Validate 验证运行手册列表
+func (rl *RunbookList) Validate() error {
+	for _, r := range rl.Runbooks {
+		if err := r.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
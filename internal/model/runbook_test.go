@@ -0,0 +1,83 @@
+package model
+
+import "testing"
+
+func newTestRunbook() *Runbook {
+	return &Runbook{
+		ID:    "k8s-pod-crashloop",
+		Title: "Pod 反复重启排查",
+		Params: []RunbookParam{
+			{Name: "namespace", Default: "default"},
+			{Name: "pod"},
+		},
+		Steps: []RunbookStep{
+			{
+				ID:       "status",
+				Title:    "查看状态",
+				Command:  "kubectl get",
+				Run:      "kubectl get pods -n {{namespace}}",
+				Branches: []RunbookBranch{{When: "CrashLoopBackOff", Then: "查看日志", Goto: "logs"}},
+			},
+			{
+				ID:      "logs",
+				Title:   "查看日志",
+				Command: "kubectl logs",
+				Run:     "kubectl logs {{pod}} -n {{namespace}} --previous",
+			},
+		},
+	}
+}
+
+func TestRunbookValidate(t *testing.T) {
+	if err := newTestRunbook().Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(r *Runbook)
+	}{
+		{"missing steps", func(r *Runbook) { r.Steps = nil }},
+		{"step without run", func(r *Runbook) { r.Steps[1].Run = "" }},
+		{"undeclared parameter", func(r *Runbook) { r.Steps[0].Run = "kubectl get pods -n {{ns}}" }},
+		{"unknown goto", func(r *Runbook) { r.Steps[0].Branches[0].Goto = "rollback" }},
+		{"duplicate step id", func(r *Runbook) { r.Steps[1].ID = "status" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRunbook()
+			tt.modify(r)
+			if err := r.Validate(); err == nil {
+				t.Error("Validate() expected error")
+			}
+		})
+	}
+}
+
+func TestRunbookRenderStep(t *testing.T) {
+	r := newTestRunbook()
+
+	if missing := r.MissingParams(nil); len(missing) != 1 || missing[0] != "pod" {
+		t.Errorf("MissingParams() = %v", missing)
+	}
+
+	got := r.RenderStep(1, map[string]string{"pod": "api-0", "namespace": "payments"})
+	if got != "kubectl logs api-0 -n payments --previous" {
+		t.Errorf("RenderStep() = %q", got)
+	}
+
+	if got := r.RenderStep(0, nil); got != "kubectl get pods -n default" {
+		t.Errorf("RenderStep() with defaults = %q", got)
+	}
+
+	// 参数值不能注入额外的 shell 命令
+	got = r.RenderStep(1, map[string]string{"pod": "api-0; kubectl delete ns prod", "namespace": "it's"})
+	if got != `kubectl logs 'api-0; kubectl delete ns prod' -n 'it'\''s' --previous` {
+		t.Errorf("RenderStep() with shell metacharacters = %q", got)
+	}
+
+	if r.StepIndex("logs") != 1 || r.StepIndex("rollback") != -1 {
+		t.Error("StepIndex() returned unexpected result")
+	}
+}
@@ -0,0 +1,30 @@
+package model
+
+import (
+	"regexp"
+	"strings"
+)
+
+// shellSafePattern 无需引用的参数：只含字母、数字和常见路径、选项字符
+var shellSafePattern = regexp.MustCompile(`^[\w@%+=:,./-]+$`)
+
+// ShellQuote 按 POSIX shell 规则引用单个参数：无需引用时原样返回，否则用单引号包裹；
+// {{变量}} 占位符视为安全字符，保持可读并可在之后替换
+func ShellQuote(arg string) string {
+	if arg == "" {
+		return "''"
+	}
+	if shellSafePattern.MatchString(placeholderPattern.ReplaceAllString(arg, "x")) {
+		return arg
+	}
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
+
+// ShellJoin 引用各参数后以空格连接，得到与参数列表等价的命令行
+func ShellJoin(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = ShellQuote(arg)
+	}
+	return strings.Join(quoted, " ")
+}
@@ -0,0 +1,21 @@
+package model
+
+import "testing"
+
+func TestShellJoin(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"kubectl", "port-forward", "-n", "payments", "svc/api", "8080:80"}, "kubectl port-forward -n payments svc/api 8080:80"},
+		{[]string{"kubectl", "exec", "-it", "mypod", "--", "sh", "-c", "echo a b"}, "kubectl exec -it mypod -- sh -c 'echo a b'"},
+		{[]string{"psql", "-h", "{{host}}", "-U", "readonly", "{{db}}"}, "psql -h {{host}} -U readonly {{db}}"},
+		{[]string{"grep", "it's", "*.log", ""}, `grep 'it'\''s' '*.log' ''`},
+		{[]string{"echo", "$HOME", "a;b", "{a,b}"}, "echo '$HOME' 'a;b' '{a,b}'"},
+	}
+	for _, tt := range tests {
+		if got := ShellJoin(tt.args); got != tt.want {
+			t.Errorf("ShellJoin(%q) = %s, want %s", tt.args, got, tt.want)
+		}
+	}
+}
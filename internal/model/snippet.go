@@ -0,0 +1,167 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Snippet 个人保存的具体命令写法，关联到它来源的命令
+type Snippet struct {
+	Name        string            `json:"name"`                // 片段名称（唯一）
+	CommandName string            `json:"command_name"`        // 来源命令名称
+	Command     string            `json:"command"`             // 命令行，可用 {{变量}} 占位
+	Description string            `json:"description"`         // 描述
+	Tags        []string          `json:"tags,omitempty"`      // 标签
+	Variables   map[string]string `json:"variables,omitempty"` // 变量及默认值
+	CreatedAt   time.Time         `json:"created_at"`          // 创建时间
+}
+
+// placeholderPattern 匹配 {{变量}} 占位符
+var placeholderPattern = regexp.MustCompile(`\{\{\s*([\w.-]+)\s*\}\}`)
+
+// placeholders 模板中出现的变量名，按出现顺序去重
+func placeholders(template string) []string {
+	var names []string
+	for _, m := range placeholderPattern.FindAllStringSubmatch(template, -1) {
+		names = appendUnique(names, []string{m[1]})
+	}
+	return names
+}
+
+// fillPlaceholders 依次在 sources 中查找变量值替换占位符，都没有时保留占位符
+func fillPlaceholders(template string, sources ...map[string]string) string {
+	return placeholderPattern.ReplaceAllStringFunc(template, func(placeholder string) string {
+		name := placeholderPattern.FindStringSubmatch(placeholder)[1]
+		for _, values := range sources {
+			if v, ok := values[name]; ok {
+				return v
+			}
+		}
+		return placeholder
+	})
+}
+
+// Placeholders 命令行中出现的变量名，按出现顺序去重
+func (s Snippet) Placeholders() []string {
+	return placeholders(s.Command)
+}
+
+// Render 替换变量：优先使用 values，其次使用默认值，都没有时保留占位符
+func (s Snippet) Render(values map[string]string) string {
+	return fillPlaceholders(s.Command, values, s.Variables)
+}
+
+// Matches 检查片段的名称、命令行、描述或标签是否包含查询词（不区分大小写）
+func (s Snippet) Matches(query string) bool {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return false
+	}
+
+	fields := append([]string{s.Name, s.CommandName, s.Command, s.Description}, s.Tags...)
+	for _, field := range fields {
+		if strings.Contains(strings.ToLower(field), query) {
+			return true
+		}
+	}
+	return false
+}
+
+// AddSnippet 添加片段，未指定名称时自动生成
+func (u *UserData) AddSnippet(snippet Snippet) (*Snippet, error) {
+	if strings.TrimSpace(snippet.Command) == "" {
+		return nil, fmt.Errorf("片段命令行不能为空")
+	}
+
+	if snippet.Name == "" {
+		for i := len(u.Snippets) + 1; ; i++ {
+			name := fmt.Sprintf("snippet-%d", i)
+			if u.FindSnippet(name) == nil {
+				snippet.Name = name
+				break
+			}
+		}
+	} else if u.FindSnippet(snippet.Name) != nil {
+		return nil, fmt.Errorf("片段已存在: %s", snippet.Name)
+	}
+
+	snippet.Tags = appendUnique(nil, snippet.Tags)
+	if snippet.CreatedAt.IsZero() {
+		snippet.CreatedAt = time.Now()
+	}
+
+	u.Snippets = append(u.Snippets, snippet)
+	return &u.Snippets[len(u.Snippets)-1], nil
+}
+
+// RemoveSnippet 删除片段，不存在时返回 false
+func (u *UserData) RemoveSnippet(name string) bool {
+	for i, snippet := range u.Snippets {
+		if snippet.Name == name {
+			u.Snippets = append(u.Snippets[:i], u.Snippets[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// FindSnippet 查找片段，不存在时返回 nil
+func (u *UserData) FindSnippet(name string) *Snippet {
+	for i := range u.Snippets {
+		if u.Snippets[i].Name == name {
+			return &u.Snippets[i]
+		}
+	}
+	return nil
+}
+
+// SnippetsFor 获取某个命令下的所有片段
+func (u *UserData) SnippetsFor(commandName string) []Snippet {
+	var result []Snippet
+	for _, snippet := range u.Snippets {
+		if snippet.CommandName == commandName {
+			result = append(result, snippet)
+		}
+	}
+	return result
+}
+
+// FilterSnippets 按命令和标签筛选片段，参数为空表示不限
+func (u *UserData) FilterSnippets(commandName, tag string) []Snippet {
+	var result []Snippet
+	for _, snippet := range u.Snippets {
+		if commandName != "" && snippet.CommandName != commandName {
+			continue
+		}
+		if tag != "" && !containsString(snippet.Tags, tag) {
+			continue
+		}
+		result = append(result, snippet)
+	}
+	return result
+}
+
+// SearchSnippets 搜索片段，多个关键词需全部命中
+func (u *UserData) SearchSnippets(query string) []Snippet {
+	words := strings.Fields(query)
+	if len(words) == 0 {
+		return nil
+	}
+
+	var result []Snippet
+	for _, snippet := range u.Snippets {
+		matched := true
+		for _, word := range words {
+			if !snippet.Matches(word) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			result = append(result, snippet)
+		}
+	}
+	return result
+}
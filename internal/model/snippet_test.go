@@ -0,0 +1,76 @@
+package model
+
+import "testing"
+
+func TestSnippetRender(t *testing.T) {
+	snippet := Snippet{
+		Command:   "kubectl port-forward -n {{ns}} svc/{{ service }} {{port}}:80",
+		Variables: map[string]string{"ns": "payments", "port": "8080"},
+	}
+
+	if got := snippet.Placeholders(); len(got) != 3 || got[1] != "service" {
+		t.Errorf("Placeholders() = %v", got)
+	}
+
+	got := snippet.Render(map[string]string{"service": "api", "port": "9090"})
+	want := "kubectl port-forward -n payments svc/api 9090:80"
+	if got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+
+	// 没有值的变量保留占位符
+	if got := snippet.Render(nil); got != "kubectl port-forward -n payments svc/{{ service }} 8080:80" {
+		t.Errorf("Render(nil) = %q", got)
+	}
+}
+
+func TestUserDataSnippets(t *testing.T) {
+	userData := NewUserData()
+
+	if _, err := userData.AddSnippet(Snippet{CommandName: "psql"}); err == nil {
+		t.Error("Expected error for empty command line")
+	}
+
+	pf, err := userData.AddSnippet(Snippet{
+		Name:        "pf-api",
+		CommandName: "kubectl port-forward",
+		Command:     "kubectl port-forward -n payments svc/api 8080:80",
+		Description: "转发支付服务 API",
+		Tags:        []string{"k8s", "k8s"},
+	})
+	if err != nil {
+		t.Fatalf("AddSnippet() error = %v", err)
+	}
+	if len(pf.Tags) != 1 || pf.CreatedAt.IsZero() {
+		t.Errorf("Unexpected snippet: %+v", pf)
+	}
+
+	if _, err := userData.AddSnippet(Snippet{Name: "pf-api", Command: "x"}); err == nil {
+		t.Error("Expected error for duplicate name")
+	}
+
+	auto, err := userData.AddSnippet(Snippet{CommandName: "psql", Command: "psql -h db.internal -U readonly"})
+	if err != nil {
+		t.Fatalf("AddSnippet() error = %v", err)
+	}
+	if auto.Name != "snippet-2" {
+		t.Errorf("Expected generated name snippet-2, got %q", auto.Name)
+	}
+
+	if got := userData.SnippetsFor("kubectl port-forward"); len(got) != 1 {
+		t.Errorf("Expected 1 snippet for kubectl port-forward, got %d", len(got))
+	}
+	if got := userData.FilterSnippets("", "k8s"); len(got) != 1 || got[0].Name != "pf-api" {
+		t.Errorf("Unexpected filter result: %+v", got)
+	}
+	if got := userData.SearchSnippets("支付 8080"); len(got) != 1 {
+		t.Errorf("Expected 1 search result, got %d", len(got))
+	}
+	if got := userData.SearchSnippets("readonly"); len(got) != 1 || got[0].CommandName != "psql" {
+		t.Errorf("Unexpected search result: %+v", got)
+	}
+
+	if !userData.RemoveSnippet("pf-api") || userData.FindSnippet("pf-api") != nil {
+		t.Error("RemoveSnippet failed")
+	}
+}
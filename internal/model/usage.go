@@ -0,0 +1,310 @@
+package model
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// UsageEvent 一次命令查看记录，使用短键名以保持日志紧凑
+type UsageEvent struct {
+	Command  string `json:"c"`           // 命令名称
+	Category string `json:"g"`           // 分类
+	Time     int64  `json:"t"`           // 访问时间（Unix秒）
+	Count    int    `json:"n,omitempty"` // 压缩后合并的查看次数，0 表示一次
+}
+
+// views 该记录代表的查看次数
+func (e UsageEvent) views() int {
+	if e.Count > 0 {
+		return e.Count
+	}
+	return 1
+}
+
+// UsageLog 本地使用日志，每行一个 UsageEvent（JSON Lines），日常访问只追加写入
+type UsageLog struct {
+	Events []UsageEvent
+}
+
+// 使用日志压缩：记录数超过 UsageCompactThreshold 时，把早于 usageCompactAge 的记录
+// 按命令、分类和月份合并。该年龄与 recencyWeight 的最低一档一致，合并不影响 frecency
+const (
+	UsageCompactThreshold = 5000
+	usageCompactAge       = 90 * 24 * time.Hour
+)
+
+// CommandUsage 单个命令的使用统计
+type CommandUsage struct {
+	Command  string    `json:"command" yaml:"command"`
+	Category string    `json:"category" yaml:"category"`
+	Count    int       `json:"count" yaml:"count"`
+	First    time.Time `json:"first_accessed" yaml:"first_accessed"`
+	Last     time.Time `json:"last_accessed" yaml:"last_accessed"`
+}
+
+// CategoryPeriod 某个时间段内各分类的查看次数
+type CategoryPeriod struct {
+	Period string         `json:"period" yaml:"period"` // 如 2026-10 或 2026-W42
+	Counts map[string]int `json:"counts" yaml:"counts"`
+}
+
+// 统计周期
+const (
+	PeriodWeek  = "week"
+	PeriodMonth = "month"
+)
+
+// LoadUsageLog 从文件加载使用日志，文件不存在时返回空日志；损坏的行会被跳过
+func LoadUsageLog(path string) (*UsageLog, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return &UsageLog{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("读取使用日志失败: %w", err)
+	}
+	defer f.Close()
+
+	log := &UsageLog{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var event UsageEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil || event.Command == "" {
+			continue
+		}
+		log.Events = append(log.Events, event)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("读取使用日志失败: %w", err)
+	}
+
+	return log, nil
+}
+
+// Append 记录一次访问并追加写入日志文件
+func (l *UsageLog) Append(path string, event UsageEvent) error {
+	l.Events = append(l.Events, event)
+
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("创建用户数据目录失败: %w", err)
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("序列化使用记录失败: %w", err)
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("写入使用日志失败: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("写入使用日志失败: %w", err)
+	}
+
+	return nil
+}
+
+// Save 重写整个日志文件
+func (l *UsageLog) Save(path string) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("创建用户数据目录失败: %w", err)
+	}
+
+	var buf bytes.Buffer
+	for _, event := range l.Events {
+		data, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("序列化使用记录失败: %w", err)
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("写入使用日志失败: %w", err)
+	}
+
+	return nil
+}
+
+// Compact 合并早于 usageCompactAge 的记录：同一命令、分类和月份最多保留两条，
+// 分别记录首次和最近访问时间，查看次数计入 Count。返回日志是否有变化
+func (l *UsageLog) Compact(now time.Time) bool {
+	cutoff := now.Add(-usageCompactAge).Unix()
+
+	type groupKey struct{ command, category, month string }
+	type group struct {
+		first, last UsageEvent
+		views       int
+	}
+	groups := make(map[groupKey]*group)
+	var keys []groupKey
+	var recent []UsageEvent
+	old := 0
+
+	for _, event := range l.Events {
+		if event.Time >= cutoff {
+			recent = append(recent, event)
+			continue
+		}
+		old++
+		key := groupKey{event.Command, event.Category, periodKey(time.Unix(event.Time, 0), PeriodMonth)}
+		g, ok := groups[key]
+		if !ok {
+			g = &group{first: event, last: event}
+			groups[key] = g
+			keys = append(keys, key)
+		}
+		g.views += event.views()
+		if event.Time < g.first.Time {
+			g.first = event
+		}
+		if event.Time > g.last.Time {
+			g.last = event
+		}
+	}
+
+	var compacted []UsageEvent
+	for _, key := range keys {
+		g := groups[key]
+		first := UsageEvent{Command: key.command, Category: key.category, Time: g.first.Time}
+		if g.views == 1 {
+			compacted = append(compacted, first)
+			continue
+		}
+		first.Count = 1
+		compacted = append(compacted, first, UsageEvent{Command: key.command, Category: key.category, Time: g.last.Time, Count: g.views - 1})
+	}
+	if len(compacted) >= old {
+		return false
+	}
+
+	sort.SliceStable(compacted, func(i, j int) bool {
+		return compacted[i].Time < compacted[j].Time
+	})
+	l.Events = append(compacted, recent...)
+	return true
+}
+
+// TotalViews 查看总次数
+func (l *UsageLog) TotalViews() int {
+	total := 0
+	for _, event := range l.Events {
+		total += event.views()
+	}
+	return total
+}
+
+// CommandUsage 按命令汇总，按查看次数降序、最近访问时间降序排列
+func (l *UsageLog) CommandUsage() []CommandUsage {
+	byName := make(map[string]*CommandUsage)
+	for _, event := range l.Events {
+		t := time.Unix(event.Time, 0)
+		u, ok := byName[event.Command]
+		if !ok {
+			u = &CommandUsage{Command: event.Command, First: t}
+			byName[event.Command] = u
+		}
+		u.Count += event.views()
+		u.Category = event.Category
+		if t.Before(u.First) {
+			u.First = t
+		}
+		if t.After(u.Last) {
+			u.Last = t
+		}
+	}
+
+	usage := make([]CommandUsage, 0, len(byName))
+	for _, u := range byName {
+		usage = append(usage, *u)
+	}
+	sort.Slice(usage, func(i, j int) bool {
+		if usage[i].Count != usage[j].Count {
+			return usage[i].Count > usage[j].Count
+		}
+		if !usage[i].Last.Equal(usage[j].Last) {
+			return usage[i].Last.After(usage[j].Last)
+		}
+		return usage[i].Command < usage[j].Command
+	})
+
+	return usage
+}
+
+// CategoryTimeline 按周或月统计各分类的查看次数，按时间升序排列
+func (l *UsageLog) CategoryTimeline(period string) []CategoryPeriod {
+	byPeriod := make(map[string]map[string]int)
+	for _, event := range l.Events {
+		key := periodKey(time.Unix(event.Time, 0), period)
+		if byPeriod[key] == nil {
+			byPeriod[key] = make(map[string]int)
+		}
+		byPeriod[key][event.Category] += event.views()
+	}
+
+	timeline := make([]CategoryPeriod, 0, len(byPeriod))
+	for key, counts := range byPeriod {
+		timeline = append(timeline, CategoryPeriod{Period: key, Counts: counts})
+	}
+	sort.Slice(timeline, func(i, j int) bool {
+		return timeline[i].Period < timeline[j].Period
+	})
+
+	return timeline
+}
+
+// periodKey 时间所在周期的标识
+func periodKey(t time.Time, period string) string {
+	if period == PeriodWeek {
+		year, week := t.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	}
+	return t.Format("2006-01")
+}
+
+// Viewed 查看过的命令集合
+func (l *UsageLog) Viewed() map[string]bool {
+	viewed := make(map[string]bool)
+	for _, event := range l.Events {
+		viewed[event.Command] = true
+	}
+	return viewed
+}
+
+// Frecency 计算每个命令的 frecency 分数：每次访问按距今时间衰减加权后累加
+func (l *UsageLog) Frecency(now time.Time) map[string]float64 {
+	scores := make(map[string]float64)
+	for _, event := range l.Events {
+		scores[event.Command] += recencyWeight(now.Sub(time.Unix(event.Time, 0))) * float64(event.views())
+	}
+	return scores
+}
+
+// recencyWeight 访问时间距今越近权重越高
+func recencyWeight(age time.Duration) float64 {
+	day := 24 * time.Hour
+	switch {
+	case age < 4*day:
+		return 100
+	case age < 14*day:
+		return 70
+	case age < 31*day:
+		return 50
+	case age < 90*day:
+		return 30
+	default:
+		return 10
+	}
+}
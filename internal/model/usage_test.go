@@ -0,0 +1,136 @@
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestUsageLog_AppendAndLoad(t *testing.T) {
+	tmpDir := t.TempDir()
+	logPath := filepath.Join(tmpDir, "usage.log")
+
+	log, err := LoadUsageLog(logPath)
+	if err != nil {
+		t.Fatalf("LoadUsageLog() error = %v", err)
+	}
+	if len(log.Events) != 0 {
+		t.Errorf("Expected empty log, got %d events", len(log.Events))
+	}
+
+	now := time.Now().Unix()
+	log.Append(logPath, UsageEvent{Command: "ls", Category: "os", Time: now})
+	log.Append(logPath, UsageEvent{Command: "kubectl get", Category: "k8s", Time: now})
+
+	// 损坏的行应被跳过
+	f, err := os.OpenFile(logPath, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatalf("Failed to open log: %v", err)
+	}
+	f.WriteString("{broken\n")
+	f.Close()
+
+	loaded, err := LoadUsageLog(logPath)
+	if err != nil {
+		t.Fatalf("LoadUsageLog() error = %v", err)
+	}
+	if len(loaded.Events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(loaded.Events))
+	}
+	if loaded.Events[1].Command != "kubectl get" || loaded.Events[1].Time != now {
+		t.Errorf("Unexpected event: %+v", loaded.Events[1])
+	}
+}
+
+func TestUsageLog_Stats(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	day := int64(24 * 60 * 60)
+
+	log := &UsageLog{Events: []UsageEvent{
+		{Command: "ls", Category: "os", Time: now.Unix() - 100*day},
+		{Command: "ls", Category: "os", Time: now.Unix() - 99*day},
+		{Command: "ls", Category: "os", Time: now.Unix() - 98*day},
+		{Command: "kubectl get", Category: "k8s", Time: now.Unix() - 1*day},
+		{Command: "kubectl get", Category: "k8s", Time: now.Unix()},
+	}}
+
+	usage := log.CommandUsage()
+	if len(usage) != 2 {
+		t.Fatalf("Expected 2 commands, got %d", len(usage))
+	}
+	if usage[0].Command != "ls" || usage[0].Count != 3 {
+		t.Errorf("Expected ls viewed 3 times first, got %+v", usage[0])
+	}
+	if !usage[1].Last.Equal(now) {
+		t.Errorf("Expected last access %v, got %v", now, usage[1].Last)
+	}
+
+	// 最近访问的命令 frecency 更高，即使次数更少
+	scores := log.Frecency(now)
+	if scores["kubectl get"] <= scores["ls"] {
+		t.Errorf("Expected recent command to rank higher: %v", scores)
+	}
+
+	timeline := log.CategoryTimeline(PeriodMonth)
+	if len(timeline) != 2 {
+		t.Fatalf("Expected 2 periods, got %+v", timeline)
+	}
+	if timeline[1].Period != "2026-10" || timeline[1].Counts["k8s"] != 2 {
+		t.Errorf("Unexpected period: %+v", timeline[1])
+	}
+
+	if !log.Viewed()["ls"] || log.Viewed()["rm"] {
+		t.Error("Viewed() returned unexpected result")
+	}
+}
+
+func TestUsageLog_Compact(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	day := int64(24 * 60 * 60)
+
+	log := &UsageLog{}
+	for i := int64(0); i < 4; i++ {
+		log.Events = append(log.Events, UsageEvent{Command: "ls", Category: "os", Time: now.Unix() - (100-i)*day})
+	}
+	log.Events = append(log.Events,
+		UsageEvent{Command: "rm", Category: "os", Time: now.Unix() - 200*day},
+		UsageEvent{Command: "kubectl get", Category: "k8s", Time: now.Unix() - 1*day},
+		UsageEvent{Command: "kubectl get", Category: "k8s", Time: now.Unix()},
+	)
+
+	usage := log.CommandUsage()
+	timeline := log.CategoryTimeline(PeriodMonth)
+	scores := log.Frecency(now)
+
+	if !log.Compact(now) {
+		t.Fatal("Expected old events to be compacted")
+	}
+	// ls 的 4 条合并为首次和最近两条，rm 只有一条保持不变
+	if len(log.Events) != 5 || log.TotalViews() != 7 {
+		t.Fatalf("Unexpected compacted log: %+v", log.Events)
+	}
+	if log.Compact(now) {
+		t.Error("Compacting twice should not change the log")
+	}
+
+	path := filepath.Join(t.TempDir(), "usage.log")
+	if err := log.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	loaded, err := LoadUsageLog(path)
+	if err != nil {
+		t.Fatalf("LoadUsageLog() error = %v", err)
+	}
+
+	if got := loaded.CommandUsage(); !reflect.DeepEqual(got, usage) {
+		t.Errorf("CommandUsage() after compaction = %+v, want %+v", got, usage)
+	}
+	if got := loaded.CategoryTimeline(PeriodMonth); !reflect.DeepEqual(got, timeline) {
+		t.Errorf("CategoryTimeline() after compaction = %+v, want %+v", got, timeline)
+	}
+	if got := loaded.Frecency(now); !reflect.DeepEqual(got, scores) {
+		t.Errorf("Frecency() after compaction = %v, want %v", got, scores)
+	}
+}
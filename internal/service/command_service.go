@@ -1,38 +1,64 @@
 package service
 
 import (
+	"sync"
+
 	"github.com/cmd4coder/cmd4coder/internal/data"
 	"github.com/cmd4coder/cmd4coder/internal/model"
+	"github.com/cmd4coder/cmd4coder/pkg/platform"
 )
 
+// maxPersonalBoost 个人化评分对搜索排序的最大加成
+//
+// 匹配优先级各档相差20（精确100、前缀80、包含60、关键词40）。加成小于30，
+// 常用命令在同档内靠前，并可越过相邻的上一档（如常用的包含匹配排在前缀匹配之前、
+// 常用的前缀匹配排在精确匹配之前），但不会越过两档。
+const maxPersonalBoost = 30.0
+
 // CommandService 命令查询服务
 type CommandService struct {
 	loader *data.Loader
 	index  *data.Index
 	cache  *data.SearchCache
+
+	// 运行手册
+	runbooks []*model.Runbook
+
+	// personalScores 个人化评分来源，nil 表示不做个人化排序
+	personalScores func() map[string]float64
+
+	// host 当前主机平台，nil 表示不按平台过滤
+	host *platform.Host
+
+	// relations 命令关系邻接表，首次查询关系图时构建
+	relations   map[string][]model.Relation
+	relationsMu sync.Mutex
 }
 
 // NewCommandService 创建命令服务
 func NewCommandService(dataDir string) (*CommandService, error) {
-	loader := data.NewLoader(dataDir)
-	index := data.NewIndex()
-	cache := data.NewSearchCache(100) // 缓存最近100次搜索
+	s := &CommandService{
+		loader: data.NewLoader(dataDir),
+		index:  data.NewIndex(),
+		cache:  data.NewSearchCache(100), // 缓存最近100次搜索
+	}
 
 	// 加载所有命令并构建索引
-	commands, err := loader.LoadAllCommands()
+	commands, err := s.loader.LoadAllCommands()
 	if err != nil {
 		return nil, err
 	}
 
-	if err := index.BuildIndex(commands); err != nil {
+	s.sortCommands(commands)
+	if err := s.index.BuildIndex(commands); err != nil {
+		return nil, err
+	}
+
+	if err := s.loadRunbooks(); err != nil {
 		return nil, err
 	}
 
-	return &CommandService{
-		loader: loader,
-		index:  index,
-		cache:  cache,
-	}, nil
+	return s, nil
 }
 
 // GetCommand 根据名称获取命令
@@ -40,7 +66,7 @@ func (s *CommandService) GetCommand(name string) (*model.Command, error) {
 	return s.index.GetByName(name)
 }
 
-// ListCommandsByCategory 根据分类列出命令
+// ListCommandsByCategory 根据分类列出命令，按命令名称排列
 func (s *CommandService) ListCommandsByCategory(category string) []*model.Command {
 	return s.index.GetByCategory(category)
 }
@@ -50,8 +76,80 @@ func (s *CommandService) ListCommandsByPlatform(platform string) []*model.Comman
 	return s.index.GetByPlatform(platform)
 }
 
+// SetHost 设置当前主机平台，ForHost 据此过滤命令；nil 表示显示所有平台的命令
+func (s *CommandService) SetHost(host *platform.Host) {
+	s.host = host
+}
+
+// Host 返回当前主机平台，未设置时为 nil
+func (s *CommandService) Host() *platform.Host {
+	return s.host
+}
+
+// SupportsHost 检查命令是否适用于当前主机，未设置主机时总是适用
+func (s *CommandService) SupportsHost(cmd *model.Command) bool {
+	return s.host == nil || s.host.Matches(cmd.Platforms, cmd.Distros)
+}
+
+// ForHost 过滤出适用于当前主机的命令，保持原有顺序
+func (s *CommandService) ForHost(commands []*model.Command) []*model.Command {
+	if s.host == nil {
+		return commands
+	}
+
+	filtered := make([]*model.Command, 0, len(commands))
+	for _, cmd := range commands {
+		if s.SupportsHost(cmd) {
+			filtered = append(filtered, cmd)
+		}
+	}
+	return filtered
+}
+
+// MatchCommandLine 找出命令行对应的命令，多个命令匹配时取名称最长的（如 kubectl delete 优先于 kubectl）
+func (s *CommandService) MatchCommandLine(line string) (*model.Command, error) {
+	var best *model.Command
+	for _, cmd := range s.index.GetAllCommands() {
+		if cmd.MatchesLine(line) && (best == nil || len(cmd.Name) > len(best.Name)) {
+			best = cmd
+		}
+	}
+	if best == nil {
+		return nil, model.ErrCommandNotFound{Name: line}
+	}
+	return best, nil
+}
+
+// PreviewCommandLine 把命令行改写为对应命令的预演形式
+func (s *CommandService) PreviewCommandLine(line string) (*model.Command, string, error) {
+	cmd, err := s.MatchCommandLine(line)
+	if err != nil {
+		return nil, "", err
+	}
+	preview, err := cmd.Preview(line)
+	return cmd, preview, err
+}
+
+// SetPersonalizer 设置个人化评分来源（如 ConfigService.PersonalScores），nil 关闭个人化排序
+func (s *CommandService) SetPersonalizer(scores func() map[string]float64) {
+	s.personalScores = scores
+}
+
+// Personalized 是否启用了个人化排序
+func (s *CommandService) Personalized() bool {
+	return s.personalScores != nil
+}
+
 // SearchCommands 搜索命令
 func (s *CommandService) SearchCommands(query string) []*model.Command {
+	// 个人化排序随使用记录变化，不走缓存
+	if s.personalScores != nil {
+		scores := s.personalScores()
+		return s.index.SearchWithScorer(query, func(cmd *model.Command) float64 {
+			return personalBoost(scores[cmd.Name])
+		})
+	}
+
 	// 先检查缓存
 	if commands, ok := s.cache.GetSearchResult(query); ok {
 		return commands
@@ -66,12 +164,20 @@ func (s *CommandService) SearchCommands(query string) []*model.Command {
 	return commands
 }
 
-// GetAllCategories 获取所有分类
+// personalBoost 把个人化评分平滑映射到 [0, maxPersonalBoost)
+func personalBoost(score float64) float64 {
+	if score <= 0 {
+		return 0
+	}
+	return maxPersonalBoost * score / (score + 100)
+}
+
+// GetAllCategories 获取所有分类，按元数据中的分类顺序排列
 func (s *CommandService) GetAllCategories() []string {
 	return s.index.GetAllCategories()
 }
 
-// GetAllCommands 获取所有命令
+// GetAllCommands 获取所有命令，按分类顺序和命令名称排列
 func (s *CommandService) GetAllCommands() []*model.Command {
 	return s.index.GetAllCommands()
 }
@@ -127,16 +233,44 @@ func (s *CommandService) Reload() error {
 		return err
 	}
 
+	s.sortCommands(commands)
 	if err := s.index.BuildIndex(commands); err != nil {
 		return err
 	}
 
+	if err := s.loadRunbooks(); err != nil {
+		return err
+	}
+
+	// 关系图随数据重建
+	s.relationsMu.Lock()
+	s.relations = nil
+	s.relationsMu.Unlock()
+
 	// 清空缓存
 	s.cache.Clear()
 
 	return nil
 }
 
+// loadRunbooks 加载运行手册并对照已构建的索引检查步骤引用的命令
+func (s *CommandService) loadRunbooks() error {
+	runbooks, err := s.loader.LoadAllRunbooks()
+	if err != nil {
+		return err
+	}
+	if err := s.index.ValidateRunbooks(runbooks); err != nil {
+		return err
+	}
+	s.runbooks = runbooks
+	return nil
+}
+
+// sortCommands 按元数据中的分类顺序排序命令，同一分类内按名称排序
+func (s *CommandService) sortCommands(commands []*model.Command) {
+	model.SortCommands(commands, s.loader.GetMetadata().CategoryOrder())
+}
+
 // Count 获取命令总数（别名）
 func (s *CommandService) Count() int {
 	return s.GetCommandCount()
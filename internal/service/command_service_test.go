@@ -0,0 +1,69 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/cmd4coder/cmd4coder/internal/data"
+	"github.com/cmd4coder/cmd4coder/internal/model"
+)
+
+func TestPersonalBoost(t *testing.T) {
+	if got := personalBoost(0); got != 0 {
+		t.Errorf("personalBoost(0) = %v, want 0", got)
+	}
+	if got := personalBoost(-5); got != 0 {
+		t.Errorf("personalBoost(-5) = %v, want 0", got)
+	}
+	if got := personalBoost(100); got != maxPersonalBoost/2 {
+		t.Errorf("personalBoost(100) = %v, want %v", got, maxPersonalBoost/2)
+	}
+
+	prev := 0.0
+	for _, score := range []float64{1, 10, 100, 1000, 1e9} {
+		got := personalBoost(score)
+		if got <= prev || got >= maxPersonalBoost {
+			t.Errorf("personalBoost(%v) = %v, want increasing and below %v", score, got, maxPersonalBoost)
+		}
+		prev = got
+	}
+
+	// 两档之差为40，加成不能越过两档
+	if maxPersonalBoost >= 40 {
+		t.Errorf("maxPersonalBoost = %v, must stay below two priority tiers", maxPersonalBoost)
+	}
+}
+
+func TestSearchCommandsPersonalized(t *testing.T) {
+	command := func(name, description string) *model.Command {
+		return &model.Command{Name: name, Category: "测试分类", Description: description}
+	}
+	index := data.NewIndex()
+	if err := index.BuildIndex([]*model.Command{
+		command("podman", "容器管理工具"),
+		command("kubectl describe pod", "查看详情"),
+		command("kubectl get pods", "列出 pod"),
+	}); err != nil {
+		t.Fatalf("BuildIndex() error = %v", err)
+	}
+	s := &CommandService{index: index, cache: data.NewSearchCache(10)}
+
+	first := func() string {
+		return s.SearchCommands("pod")[0].Name
+	}
+	if got := first(); got != "podman" {
+		t.Errorf("without personalization first = %q, want podman", got)
+	}
+
+	s.SetPersonalizer(func() map[string]float64 {
+		return map[string]float64{"kubectl describe pod": 500}
+	})
+	if got := first(); got != "kubectl describe pod" {
+		t.Errorf("with personalization first = %q, want kubectl describe pod", got)
+	}
+
+	// 关闭个人化后恢复原有排序
+	s.SetPersonalizer(nil)
+	if got := first(); got != "podman" {
+		t.Errorf("after disabling personalization first = %q, want podman", got)
+	}
+}
@@ -4,17 +4,22 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/cmd4coder/cmd4coder/internal/model"
+	"github.com/cmd4coder/cmd4coder/pkg/export"
 )
 
 // ConfigService 配置管理服务
 type ConfigService struct {
 	config       *model.Config
 	userData     *model.UserData
+	usageLog     *model.UsageLog
 	configPath   string
 	userDataPath string
+	usageLogPath string
 	mu           sync.RWMutex
 }
 
@@ -28,6 +33,7 @@ func NewConfigService() (*ConfigService, error) {
 	configDir := filepath.Join(homeDir, ".cmd4coder")
 	configPath := filepath.Join(configDir, "config.json")
 	userDataPath := filepath.Join(configDir, "userdata.json")
+	usageLogPath := filepath.Join(configDir, "usage.log")
 
 	// 加载配置
 	config, err := model.LoadConfig(configPath)
@@ -41,14 +47,57 @@ func NewConfigService() (*ConfigService, error) {
 		return nil, fmt.Errorf("加载用户数据失败: %w", err)
 	}
 
+	// 加载使用日志
+	usageLog, err := model.LoadUsageLog(usageLogPath)
+	if err != nil {
+		return nil, fmt.Errorf("加载使用日志失败: %w", err)
+	}
+
+	// 首次使用时以已有历史记录作为初始数据
+	if len(usageLog.Events) == 0 {
+		for i := len(userData.History) - 1; i >= 0; i-- {
+			entry := userData.History[i]
+			usageLog.Events = append(usageLog.Events, model.UsageEvent{
+				Command:  entry.CommandName,
+				Category: entry.Category,
+				Time:     entry.AccessedAt.Unix(),
+			})
+		}
+		if len(usageLog.Events) > 0 {
+			if err := usageLog.Save(usageLogPath); err != nil {
+				return nil, fmt.Errorf("保存使用日志失败: %w", err)
+			}
+		}
+	}
+
+	// 日志过大时合并旧记录，避免文件无限增长
+	if len(usageLog.Events) > model.UsageCompactThreshold && usageLog.Compact(time.Now()) {
+		if err := usageLog.Save(usageLogPath); err != nil {
+			return nil, fmt.Errorf("保存使用日志失败: %w", err)
+		}
+	}
+
 	return &ConfigService{
 		config:       config,
 		userData:     userData,
+		usageLog:     usageLog,
 		configPath:   configPath,
 		userDataPath: userDataPath,
+		usageLogPath: usageLogPath,
 	}, nil
 }
 
+// validateConfig 验证配置，并对照导出注册表检查 export.default_format
+func validateConfig(c *model.Config) error {
+	if err := c.Validate(); err != nil {
+		return err
+	}
+	if _, err := export.Get(c.Export.DefaultFormat); err != nil {
+		return fmt.Errorf("export.default_format 必须是 %s 之一", strings.Join(export.Formats(), ", "))
+	}
+	return nil
+}
+
 // GetConfig 获取配置
 func (s *ConfigService) GetConfig() *model.Config {
 	s.mu.RLock()
@@ -63,7 +112,7 @@ func (s *ConfigService) UpdateConfig(updater func(*model.Config)) error {
 
 	updater(s.config)
 
-	if err := s.config.Validate(); err != nil {
+	if err := validateConfig(s.config); err != nil {
 		return fmt.Errorf("配置验证失败: %w", err)
 	}
 
@@ -79,7 +128,7 @@ func (s *ConfigService) SaveConfig() error {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	if err := s.config.Validate(); err != nil {
+	if err := validateConfig(s.config); err != nil {
 		return fmt.Errorf("配置验证失败: %w", err)
 	}
 
@@ -135,13 +184,182 @@ func (s *ConfigService) GetFavorites() []model.Favorite {
 	return s.userData.Favorites
 }
 
-// AddHistory 添加历史记录
+// FindFavorite 获取收藏，不存在时返回 nil
+func (s *ConfigService) FindFavorite(commandName string) *model.Favorite {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	fav := s.userData.FindFavorite(commandName)
+	if fav == nil {
+		return nil
+	}
+	copied := *fav
+	return &copied
+}
+
+// SetFavoriteNote 修改收藏备注
+func (s *ConfigService) SetFavoriteNote(commandName, note string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.userData.SetFavoriteNote(commandName, note) {
+		return fmt.Errorf("命令未收藏: %s", commandName)
+	}
+	return s.userData.Save(s.userDataPath)
+}
+
+// TagFavorite 给收藏添加标签和收藏集
+func (s *ConfigService) TagFavorite(commandName string, tags, collections []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.userData.TagFavorite(commandName, tags, collections) {
+		return fmt.Errorf("命令未收藏: %s", commandName)
+	}
+	return s.userData.Save(s.userDataPath)
+}
+
+// UntagFavorite 移除收藏的标签和收藏集
+func (s *ConfigService) UntagFavorite(commandName string, tags, collections []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.userData.UntagFavorite(commandName, tags, collections) {
+		return fmt.Errorf("命令未收藏: %s", commandName)
+	}
+	return s.userData.Save(s.userDataPath)
+}
+
+// FilterFavorites 按标签和收藏集筛选收藏
+func (s *ConfigService) FilterFavorites(tag, collection string) []model.Favorite {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.userData.FilterFavorites(tag, collection)
+}
+
+// FavoriteTags 获取所有收藏标签
+func (s *ConfigService) FavoriteTags() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.userData.FavoriteTags()
+}
+
+// FavoriteCollections 获取所有收藏集
+func (s *ConfigService) FavoriteCollections() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.userData.FavoriteCollections()
+}
+
+// AddSnippet 添加个人命令片段，返回保存后的片段
+func (s *ConfigService) AddSnippet(snippet model.Snippet) (model.Snippet, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	added, err := s.userData.AddSnippet(snippet)
+	if err != nil {
+		return model.Snippet{}, err
+	}
+	return *added, s.userData.Save(s.userDataPath)
+}
+
+// RemoveSnippet 删除个人命令片段
+func (s *ConfigService) RemoveSnippet(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.userData.RemoveSnippet(name) {
+		return fmt.Errorf("片段不存在: %s", name)
+	}
+	return s.userData.Save(s.userDataPath)
+}
+
+// FindSnippet 获取个人命令片段，不存在时返回 nil
+func (s *ConfigService) FindSnippet(name string) *model.Snippet {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	snippet := s.userData.FindSnippet(name)
+	if snippet == nil {
+		return nil
+	}
+	copied := *snippet
+	return &copied
+}
+
+// SnippetsFor 获取某个命令下的个人片段
+func (s *ConfigService) SnippetsFor(commandName string) []model.Snippet {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.userData.SnippetsFor(commandName)
+}
+
+// FilterSnippets 按命令和标签筛选个人片段
+func (s *ConfigService) FilterSnippets(commandName, tag string) []model.Snippet {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.userData.FilterSnippets(commandName, tag)
+}
+
+// SearchSnippets 搜索个人片段
+func (s *ConfigService) SearchSnippets(query string) []model.Snippet {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.userData.SearchSnippets(query)
+}
+
+// AddHistory 添加历史记录，同时记录到使用日志
 func (s *ConfigService) AddHistory(commandName, category string) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	s.userData.AddHistory(commandName, category)
-	return s.userData.Save(s.userDataPath)
+	if err := s.userData.Save(s.userDataPath); err != nil {
+		return err
+	}
+
+	return s.usageLog.Append(s.usageLogPath, model.UsageEvent{
+		Command:  commandName,
+		Category: category,
+		Time:     time.Now().Unix(),
+	})
+}
+
+// GetUsageLog 获取使用日志
+func (s *ConfigService) GetUsageLog() *model.UsageLog {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.usageLog
+}
+
+// Frecency 获取每个命令的 frecency 分数
+func (s *ConfigService) Frecency() map[string]float64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.usageLog.Frecency(time.Now())
+}
+
+// favoriteBonus 收藏命令在个人化评分中的加成，相当于近期查看一次
+const favoriteBonus = 100.0
+
+// PersonalScores 个人化评分：使用日志的 frecency 加上收藏加成
+func (s *ConfigService) PersonalScores() map[string]float64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	scores := s.usageLog.Frecency(time.Now())
+	for _, fav := range s.userData.Favorites {
+		scores[fav.CommandName] += favoriteBonus
+	}
+	return scores
 }
 
 // GetRecentHistory 获取最近的历史记录
@@ -0,0 +1,30 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/cmd4coder/cmd4coder/internal/model"
+)
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		format  string
+		wantErr bool
+	}{
+		{"markdown", false},
+		{"json", false},
+		{"cheatsheet", false},
+		{"anki", false},
+		{"xml", true},
+		{"", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			cfg := model.DefaultConfig()
+			cfg.Export.DefaultFormat = tt.format
+			if err := validateConfig(cfg); (err != nil) != tt.wantErr {
+				t.Errorf("validateConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
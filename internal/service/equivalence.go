@@ -0,0 +1,78 @@
+package service
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/cmd4coder/cmd4coder/internal/model"
+	"github.com/cmd4coder/cmd4coder/pkg/platform"
+)
+
+// Equivalents 获取与命令意图相同的其他命令（按名称排序）
+func (s *CommandService) Equivalents(cmd *model.Command) []*model.Command {
+	var equivalents []*model.Command
+	for _, other := range s.index.GetAllCommands() {
+		if cmd.IsEquivalentTo(other) {
+			equivalents = append(equivalents, other)
+		}
+	}
+	sort.Slice(equivalents, func(i, j int) bool {
+		return equivalents[i].Name < equivalents[j].Name
+	})
+	return equivalents
+}
+
+// Translate 把命令行转换为目标平台上的等价命令
+//
+// 返回识别出的源命令和所有适用于目标平台的转换结果，最匹配目标发行版的排在最前。
+func (s *CommandService) Translate(line string, target platform.Host) (*model.Command, []model.Translation, error) {
+	source, err := s.MatchCommandLine(line)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var candidates []*model.Command
+	for _, cmd := range s.Equivalents(source) {
+		if target.Matches(cmd.Platforms, cmd.Distros) {
+			candidates = append(candidates, cmd)
+		}
+	}
+	if len(candidates) == 0 {
+		return source, nil, model.ErrNoEquivalent{Command: source.Name}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return distroRank(candidates[i], target) < distroRank(candidates[j], target)
+	})
+
+	translations := make([]model.Translation, 0, len(candidates))
+	for _, cmd := range candidates {
+		translation, err := source.TranslateLine(line, cmd)
+		if err != nil {
+			return source, nil, err
+		}
+		translations = append(translations, translation)
+	}
+	return source, translations, nil
+}
+
+// distroRank 命令与目标发行版的匹配程度，越小越匹配：
+// 发行版列表中越靠前越优先，其次是兼容的上游发行版，不限发行版的通用命令排在最后
+func distroRank(cmd *model.Command, target platform.Host) int {
+	if len(cmd.Distros) == 0 || target.Distro == "" {
+		return 100
+	}
+	for i, d := range cmd.Distros {
+		if strings.EqualFold(d, target.Distro) {
+			return i
+		}
+	}
+	for i, d := range cmd.Distros {
+		for _, like := range target.Like {
+			if strings.EqualFold(d, like) {
+				return 50 + i
+			}
+		}
+	}
+	return 100
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"github.com/cmd4coder/cmd4coder/internal/model"
+)
+
+// CommandFilter 按条件筛选命令，零值表示不限
+type CommandFilter struct {
+	Names      []string        // 指定的命令名称，指定后忽略其他条件
+	Query      string          // 搜索关键词
+	Categories []string        // 分类，可指定多个
+	MinRisk    model.RiskLevel // 最低风险级别
+}
+
+// FilterCommands 按条件筛选命令
+//
+// 指定命令名称时按名称原样返回；否则依次按关键词、分类和风险级别筛选，并按当前平台过滤，
+// 结果按分类顺序和命令名称排列，保证多次导出的内容一致。
+func (s *CommandService) FilterCommands(filter CommandFilter) ([]*model.Command, error) {
+	if len(filter.Names) > 0 {
+		commands := make([]*model.Command, 0, len(filter.Names))
+		for _, name := range filter.Names {
+			cmd, err := s.index.GetByName(name)
+			if err != nil {
+				return nil, err
+			}
+			commands = append(commands, cmd)
+		}
+		return commands, nil
+	}
+
+	var commands []*model.Command
+	switch {
+	case filter.Query != "":
+		commands = s.SearchCommands(filter.Query)
+	case len(filter.Categories) > 0:
+		for _, category := range filter.Categories {
+			commands = append(commands, s.index.GetByCategory(category)...)
+		}
+	default:
+		commands = s.index.GetAllCommands()
+	}
+
+	categories := make(map[string]bool, len(filter.Categories))
+	for _, category := range filter.Categories {
+		categories[category] = true
+	}
+
+	var filtered []*model.Command
+	for _, cmd := range commands {
+		if len(categories) > 0 && !categories[cmd.Category] {
+			continue
+		}
+		if filter.MinRisk != "" && !cmd.GetHighestRisk().AtLeast(filter.MinRisk) {
+			continue
+		}
+		filtered = append(filtered, cmd)
+	}
+
+	s.sortCommands(filtered)
+	return s.ForHost(filtered), nil
+}
@@ -0,0 +1,242 @@
+package service
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/cmd4coder/cmd4coder/internal/model"
+	"github.com/cmd4coder/cmd4coder/pkg/history"
+)
+
+// GapReport shell 历史覆盖分析报告
+type GapReport struct {
+	TotalInvocations   int                  // 历史中的调用总数（不含 shell 内建命令）
+	MatchedInvocations int                  // 能对应到数据集命令的调用数
+	MissingTools       []UsageCount         // 数据集中完全没有收录的工具
+	MissingSubcommands []UsageCount         // 工具已收录但该子命令未收录
+	OptionUsage        []CommandOptionUsage // 已收录命令的选项使用情况
+}
+
+// UsageCount 名称及使用次数
+type UsageCount struct {
+	Name  string
+	Count int
+}
+
+// CommandOptionUsage 单个命令的选项使用情况
+type CommandOptionUsage struct {
+	Command      string       // 命令名称
+	Count        int          // 调用次数
+	Documented   []UsageCount // 用到的已收录选项
+	Undocumented []UsageCount // 用到但数据中没有说明的选项
+}
+
+// shellBuiltins 不参与覆盖分析的 shell 内建命令（数据集收录了的除外）
+var shellBuiltins = map[string]bool{
+	"cd": true, "exit": true, "export": true, "source": true, ".": true,
+	"alias": true, "unalias": true, "history": true, "clear": true, "unset": true,
+	"set": true, "pushd": true, "popd": true, "fg": true, "bg": true,
+	"jobs": true, "type": true, "logout": true, "reset": true, "echo": true,
+	"printf": true, "read": true, "eval": true, "exec": true, "wait": true,
+	"shift": true, "return": true, "local": true, "declare": true, "umask": true,
+	"ulimit": true, "hash": true, "true": true, "false": true, "test": true, "[": true,
+}
+
+// AnalyzeHistory 把 shell 历史中的调用对应到数据集命令，找出未收录的常用工具和选项使用情况
+func (s *CommandService) AnalyzeHistory(invocations []history.Invocation) *GapReport {
+	// 按程序名分组候选命令，名称词数多的优先匹配（"kubectl rollout undo" 优先于 "kubectl"）
+	candidates := make(map[string][]*model.Command)
+	for _, cmd := range s.index.GetAllCommands() {
+		fields := strings.Fields(cmd.Name)
+		if len(fields) == 0 {
+			continue
+		}
+		candidates[fields[0]] = append(candidates[fields[0]], cmd)
+	}
+	for _, cmds := range candidates {
+		sort.Slice(cmds, func(i, j int) bool {
+			ni, nj := len(strings.Fields(cmds[i].Name)), len(strings.Fields(cmds[j].Name))
+			if ni != nj {
+				return ni > nj
+			}
+			return cmds[i].Name < cmds[j].Name
+		})
+	}
+
+	report := &GapReport{}
+	missingTools := make(map[string]int)
+	missingSubcommands := make(map[string]int)
+	usage := make(map[string]*optionCounter)
+
+	for _, inv := range invocations {
+		tokens := inv.Tokens()
+		cmds, known := candidates[inv.Program]
+		if !known && shellBuiltins[inv.Program] {
+			continue
+		}
+		report.TotalInvocations++
+
+		matched := matchInvocation(cmds, tokens)
+		if matched == nil {
+			if known {
+				missingSubcommands[subcommandKey(inv)]++
+			} else {
+				missingTools[inv.Program]++
+			}
+			continue
+		}
+
+		report.MatchedInvocations++
+		counter, ok := usage[matched.Name]
+		if !ok {
+			counter = newOptionCounter(matched)
+			usage[matched.Name] = counter
+		}
+		counter.add(tokens[len(strings.Fields(matched.Name)):])
+	}
+
+	report.MissingTools = sortedCounts(missingTools)
+	report.MissingSubcommands = sortedCounts(missingSubcommands)
+
+	for name, counter := range usage {
+		report.OptionUsage = append(report.OptionUsage, CommandOptionUsage{
+			Command:      name,
+			Count:        counter.invocations,
+			Documented:   sortedCounts(counter.documented),
+			Undocumented: sortedCounts(counter.undocumented),
+		})
+	}
+	sort.Slice(report.OptionUsage, func(i, j int) bool {
+		a, b := report.OptionUsage[i], report.OptionUsage[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.Command < b.Command
+	})
+
+	return report
+}
+
+// matchInvocation 找到名称各词与调用前缀逐词相同的命令
+func matchInvocation(cmds []*model.Command, tokens []string) *model.Command {
+	for _, cmd := range cmds {
+		fields := strings.Fields(cmd.Name)
+		if len(fields) > len(tokens) {
+			continue
+		}
+
+		matched := true
+		for i, field := range fields {
+			token := tokens[i]
+			if token != field && !strings.HasPrefix(token, field+"=") {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return cmd
+		}
+	}
+	return nil
+}
+
+// subcommandKey 未收录子命令的名称：程序名加第一个非选项参数
+func subcommandKey(inv history.Invocation) string {
+	for _, arg := range inv.Args {
+		if !strings.HasPrefix(arg, "-") {
+			return inv.Program + " " + arg
+		}
+	}
+	return inv.Program
+}
+
+// optionCounter 统计单个命令的选项使用
+type optionCounter struct {
+	flags        map[string]string // 选项写法 -> 数据中的 Flag
+	invocations  int
+	documented   map[string]int
+	undocumented map[string]int
+}
+
+// newOptionCounter 从命令的 Options 中提取所有选项写法
+func newOptionCounter(cmd *model.Command) *optionCounter {
+	c := &optionCounter{
+		flags:        make(map[string]string),
+		documented:   make(map[string]int),
+		undocumented: make(map[string]int),
+	}
+
+	for _, opt := range cmd.Options {
+		for _, part := range strings.FieldsFunc(opt.Flag, func(r rune) bool {
+			return r == ',' || r == ' ' || r == '=' || r == '|'
+		}) {
+			if strings.HasPrefix(part, "-") {
+				c.flags[part] = opt.Flag
+			}
+		}
+	}
+
+	return c
+}
+
+// add 统计一次调用中的选项
+func (c *optionCounter) add(args []string) {
+	c.invocations++
+
+	for _, arg := range args {
+		if arg == "--" {
+			break
+		}
+		if !strings.HasPrefix(arg, "-") || arg == "-" {
+			continue
+		}
+
+		flag := arg
+		if i := strings.Index(flag, "="); i > 0 {
+			flag = flag[:i]
+		}
+
+		if documented, ok := c.flags[flag]; ok {
+			c.documented[documented]++
+			continue
+		}
+
+		// 组合短选项，如 -la = -l -a
+		if !strings.HasPrefix(flag, "--") && len(flag) > 2 && c.countCombined(flag) {
+			continue
+		}
+
+		c.undocumented[flag]++
+	}
+}
+
+// countCombined 尝试按组合短选项统计，全部为已收录选项时才计入
+func (c *optionCounter) countCombined(flag string) bool {
+	var documented []string
+	for _, r := range flag[1:] {
+		d, ok := c.flags["-"+string(r)]
+		if !ok {
+			return false
+		}
+		documented = append(documented, d)
+	}
+	for _, d := range documented {
+		c.documented[d]++
+	}
+	return true
+}
+
+// sortedCounts 按次数降序、名称升序排列
+func sortedCounts(counts map[string]int) []UsageCount {
+	result := make([]UsageCount, 0, len(counts))
+	for name, count := range counts {
+		result = append(result, UsageCount{Name: name, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cmd4coder/cmd4coder/internal/data"
+	"github.com/cmd4coder/cmd4coder/internal/model"
+	"github.com/cmd4coder/cmd4coder/pkg/history"
+)
+
+func TestAnalyzeHistory(t *testing.T) {
+	index := data.NewIndex()
+	if err := index.BuildIndex([]*model.Command{
+		{Name: "kubectl get", Category: "容器", Description: "获取资源", Options: []model.Option{
+			{Flag: "-n, --namespace", Description: "命名空间"},
+			{Flag: "-o, --output", Description: "输出格式"},
+		}},
+		{Name: "ls", Category: "系统", Description: "列出目录", Options: []model.Option{
+			{Flag: "-l", Description: "长格式"},
+			{Flag: "-a", Description: "显示隐藏文件"},
+		}},
+	}); err != nil {
+		t.Fatalf("BuildIndex() error = %v", err)
+	}
+	s := &CommandService{index: index, cache: data.NewSearchCache(10)}
+
+	var invocations []history.Invocation
+	for _, line := range []string{
+		"kubectl get pods -n app -o wide",
+		"kubectl rollout undo deploy/api",
+		"ls -la",
+		"ls --color=auto",
+		"cd /tmp && terraform plan",
+		"terraform plan",
+		"sudo -n apt install curl",
+		"echo $PATH",
+		"export KUBECONFIG=~/.kube/dev && [ -f x ] && source ~/.bashrc",
+	} {
+		invocations = append(invocations, history.Parse(line)...)
+	}
+
+	report := s.AnalyzeHistory(invocations)
+	if report.TotalInvocations != 7 || report.MatchedInvocations != 3 {
+		t.Errorf("total = %d, matched = %d, want 7 and 3", report.TotalInvocations, report.MatchedInvocations)
+	}
+	if want := []UsageCount{{"terraform", 2}, {"apt", 1}}; !reflect.DeepEqual(report.MissingTools, want) {
+		t.Errorf("MissingTools = %v, want %v", report.MissingTools, want)
+	}
+	if want := []UsageCount{{"kubectl rollout", 1}}; !reflect.DeepEqual(report.MissingSubcommands, want) {
+		t.Errorf("MissingSubcommands = %v, want %v", report.MissingSubcommands, want)
+	}
+
+	want := []CommandOptionUsage{
+		{
+			Command:      "ls",
+			Count:        2,
+			Documented:   []UsageCount{{"-a", 1}, {"-l", 1}},
+			Undocumented: []UsageCount{{"--color", 1}},
+		},
+		{
+			Command:      "kubectl get",
+			Count:        1,
+			Documented:   []UsageCount{{"-n, --namespace", 1}, {"-o, --output", 1}},
+			Undocumented: []UsageCount{},
+		},
+	}
+	if !reflect.DeepEqual(report.OptionUsage, want) {
+		t.Errorf("OptionUsage = %+v, want %+v", report.OptionUsage, want)
+	}
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/cmd4coder/cmd4coder/internal/model"
+)
+
+// Neighbors 获取以命令为中心、depth 步以内的关系图
+//
+// 关系包括相关命令、等价命令和更安全的替代命令，不论声明方向都可以沿关系走到对方；
+// 数据集中不存在的命令不会出现在图中。depth 小于 1 时按 1 处理。
+func (s *CommandService) Neighbors(name string, depth int) (*model.CommandGraph, error) {
+	root, err := s.index.GetByName(name)
+	if err != nil {
+		return nil, err
+	}
+	if depth < 1 {
+		depth = 1
+	}
+
+	adjacency := s.relationIndex()
+	graph := &model.CommandGraph{Root: root.Name, Nodes: []string{root.Name}}
+	visited := map[string]bool{root.Name: true}
+	seenEdges := make(map[model.Relation]bool)
+
+	frontier := []string{root.Name}
+	for level := 0; level < depth && len(frontier) > 0; level++ {
+		var next []string
+		for _, node := range frontier {
+			for _, rel := range adjacency[node] {
+				other := rel.Other(node)
+				if !visited[other] {
+					visited[other] = true
+					next = append(next, other)
+				}
+			}
+		}
+		sort.Strings(next)
+		graph.Nodes = append(graph.Nodes, next...)
+		frontier = next
+	}
+
+	// 收集两端都在图中的关系
+	for _, node := range graph.Nodes {
+		for _, rel := range adjacency[node] {
+			if visited[rel.From] && visited[rel.To] && !seenEdges[rel] {
+				seenEdges[rel] = true
+				graph.Edges = append(graph.Edges, rel)
+			}
+		}
+	}
+	graph.SortEdges()
+
+	return graph, nil
+}
+
+// relationIndex 返回命令关系邻接表，每条关系同时挂在两端命令下
+func (s *CommandService) relationIndex() map[string][]model.Relation {
+	s.relationsMu.Lock()
+	defer s.relationsMu.Unlock()
+
+	if s.relations != nil {
+		return s.relations
+	}
+
+	commands := s.index.GetAllCommands()
+	exists := make(map[string]bool, len(commands))
+	byIntent := make(map[string][]string)
+	for _, cmd := range commands {
+		exists[cmd.Name] = true
+		if intent := strings.ToLower(cmd.Intent()); intent != "" {
+			byIntent[intent] = append(byIntent[intent], cmd.Name)
+		}
+	}
+
+	adjacency := make(map[string][]model.Relation)
+	seen := make(map[model.Relation]bool)
+	add := func(rel model.Relation) {
+		if !exists[rel.From] || !exists[rel.To] || seen[rel] {
+			return
+		}
+		seen[rel] = true
+		adjacency[rel.From] = append(adjacency[rel.From], rel)
+		adjacency[rel.To] = append(adjacency[rel.To], rel)
+	}
+
+	for _, cmd := range commands {
+		for _, rel := range cmd.Relations() {
+			add(rel)
+		}
+	}
+
+	// 等价关系无方向，统一按名称顺序记录一次
+	for _, names := range byIntent {
+		sort.Strings(names)
+		for i := range names {
+			for j := i + 1; j < len(names); j++ {
+				add(model.Relation{From: names[i], To: names[j], Kind: model.RelationEquivalent})
+			}
+		}
+	}
+
+	s.relations = adjacency
+	return adjacency
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"sort"
+
+	"github.com/cmd4coder/cmd4coder/internal/model"
+	"github.com/cmd4coder/cmd4coder/pkg/history"
+)
+
+// ListRunbooks 获取所有运行手册（按ID排序）
+func (s *CommandService) ListRunbooks() []*model.Runbook {
+	runbooks := make([]*model.Runbook, len(s.runbooks))
+	copy(runbooks, s.runbooks)
+	sort.Slice(runbooks, func(i, j int) bool {
+		return runbooks[i].ID < runbooks[j].ID
+	})
+	return runbooks
+}
+
+// GetRunbook 根据ID获取运行手册
+func (s *CommandService) GetRunbook(id string) (*model.Runbook, error) {
+	for _, r := range s.runbooks {
+		if r.ID == id {
+			return r, nil
+		}
+	}
+	return nil, model.ErrRunbookNotFound{ID: id}
+}
+
+// RunbookStepRisk 步骤的风险级别：取引用的命令与实际运行的命令行（按 && | 等拆开后逐段匹配）中最高的风险。
+// 有未收录的命令段且其余风险低于高风险时返回空，表示风险未知
+func (s *CommandService) RunbookStepRisk(step model.RunbookStep, run string) model.RiskLevel {
+	var risk model.RiskLevel
+	unknown := false
+	if cmd := s.RunbookStepCommand(step); cmd != nil {
+		risk = cmd.GetRiskLevel()
+	} else {
+		unknown = true
+	}
+
+	for _, inv := range history.Parse(run) {
+		cmd, err := s.MatchCommandLine(inv.Line)
+		if err != nil {
+			unknown = true
+			continue
+		}
+		risk = model.HigherRisk(risk, cmd.GetRiskLevel())
+	}
+
+	if unknown && risk != model.RiskLevelHigh && risk != model.RiskLevelCritical {
+		return ""
+	}
+	return risk
+}
+
+// RunbookStepCommand 获取步骤引用的命令，命令不在数据集中时返回 nil
+func (s *CommandService) RunbookStepCommand(step model.RunbookStep) *model.Command {
+	cmd, err := s.index.GetByName(step.Command)
+	if err != nil {
+		return nil
+	}
+	return cmd
+}
@@ -0,0 +1,42 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/cmd4coder/cmd4coder/internal/data"
+	"github.com/cmd4coder/cmd4coder/internal/model"
+)
+
+func TestRunbookStepRisk(t *testing.T) {
+	command := func(name string, level model.RiskLevel) *model.Command {
+		return &model.Command{Name: name, Category: "测试分类", Description: name, Risks: []model.Risk{{Level: level, Description: "风险"}}}
+	}
+	index := data.NewIndex()
+	if err := index.BuildIndex([]*model.Command{
+		command("kubectl get", model.RiskLevelLow),
+		command("kubectl delete", model.RiskLevelCritical),
+		command("grep", model.RiskLevelLow),
+	}); err != nil {
+		t.Fatalf("BuildIndex() error = %v", err)
+	}
+	s := &CommandService{index: index, cache: data.NewSearchCache(10)}
+
+	tests := []struct {
+		command string
+		run     string
+		want    model.RiskLevel
+	}{
+		{"kubectl get", "kubectl get pods -n app", model.RiskLevelLow},
+		{"kubectl get", "kubectl get pods | grep api", model.RiskLevelLow},
+		{"kubectl get", "kubectl delete ns prod", model.RiskLevelCritical},
+		{"kubectl get", "kubectl get pods && sudo kubectl delete ns prod", model.RiskLevelCritical},
+		{"kubectl get", "kubectl get pods | xargs rm", ""},
+		{"helm list", "helm list", ""},
+	}
+	for _, tt := range tests {
+		step := model.RunbookStep{Title: "步骤", Command: tt.command, Run: tt.run}
+		if got := s.RunbookStepRisk(step, tt.run); got != tt.want {
+			t.Errorf("RunbookStepRisk(%q, %q) = %q, want %q", tt.command, tt.run, got, tt.want)
+		}
+	}
+}
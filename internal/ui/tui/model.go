@@ -2,6 +2,8 @@ package tui
 
 import (
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
@@ -10,6 +12,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/cmd4coder/cmd4coder/internal/model"
 	"github.com/cmd4coder/cmd4coder/internal/service"
+	"github.com/cmd4coder/cmd4coder/pkg/export"
 )
 
 // Model TUI模型
@@ -23,16 +26,27 @@ type Model struct {
 	commands    []*model.Command
 	selectedCmd *model.Command
 
+	// 详情面板中的关联命令及浏览历史
+	links     []model.Relation
+	linkIndex int
+	back      []*model.Command
+	forward   []*model.Command
+
 	// UI组件
 	searchInput  textinput.Model
 	categoryList list.Model
 	commandList  list.Model
 
+	// 收藏夹筛选项（全部、按标签、按收藏集）
+	favoriteFilters []favoriteFilter
+
 	// 状态
-	activePanel int // 0: search, 1: category, 2: command, 3: detail
-	width       int
-	height      int
-	ready       bool
+	activePanel  int  // 0: search, 1: category, 2: command, 3: detail
+	favoriteMode bool // 分类面板显示收藏夹
+	statusMsg    string
+	width        int
+	height       int
+	ready        bool
 
 	// 键盘绑定
 	keys keyMap
@@ -48,7 +62,10 @@ type keyMap struct {
 	Tab      key.Binding
 	Search   key.Binding
 	Favorite key.Binding
+	FavPanel key.Binding
 	Export   key.Binding
+	Back     key.Binding
+	Forward  key.Binding
 	Help     key.Binding
 	Quit     key.Binding
 }
@@ -87,10 +104,22 @@ var defaultKeys = keyMap{
 		key.WithKeys("f"),
 		key.WithHelp("f", "收藏"),
 	),
+	FavPanel: key.NewBinding(
+		key.WithKeys("F"),
+		key.WithHelp("F", "收藏夹"),
+	),
 	Export: key.NewBinding(
 		key.WithKeys("e"),
 		key.WithHelp("e", "导出"),
 	),
+	Back: key.NewBinding(
+		key.WithKeys("backspace", "["),
+		key.WithHelp("[", "后退"),
+	),
+	Forward: key.NewBinding(
+		key.WithKeys("]"),
+		key.WithHelp("]", "前进"),
+	),
 	Help: key.NewBinding(
 		key.WithKeys("?"),
 		key.WithHelp("?", "帮助"),
@@ -141,6 +170,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyMsg:
+		m.statusMsg = ""
+
 		// 全局快捷键
 		switch {
 		case key.Matches(msg, m.keys.Quit):
@@ -193,8 +224,18 @@ func (m Model) handlePanelInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				m.activePanel = 0
 				m.searchInput.Focus()
 			}
+		case key.Matches(msg, m.keys.FavPanel):
+			m.toggleFavoritePanel()
+			return m, nil
+		case key.Matches(msg, m.keys.Export):
+			m.exportCommands()
+			return m, nil
 		case key.Matches(msg, m.keys.Enter), key.Matches(msg, m.keys.Right):
-			m.loadCategoryCommands()
+			if m.favoriteMode {
+				m.loadFavoriteCommands()
+			} else {
+				m.loadCategoryCommands()
+			}
 			m.activePanel = 2
 			m.updateFocus()
 			return m, nil
@@ -210,11 +251,50 @@ func (m Model) handlePanelInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		case key.Matches(msg, m.keys.Enter):
 			m.loadCommandDetail()
 			return m, nil
+		case key.Matches(msg, m.keys.Right):
+			if m.selectedCmd == nil {
+				m.loadCommandDetail()
+			}
+			if m.selectedCmd != nil {
+				m.activePanel = 3
+			}
+			return m, nil
 		case key.Matches(msg, m.keys.Favorite):
 			m.toggleFavorite()
 			return m, nil
+		case key.Matches(msg, m.keys.FavPanel):
+			m.toggleFavoritePanel()
+			return m, nil
+		case key.Matches(msg, m.keys.Export):
+			m.exportCommands()
+			return m, nil
 		}
 		m.commandList, cmd = m.commandList.Update(msg)
+
+	case 3: // 详情面板，浏览关联命令
+		switch {
+		case key.Matches(msg, m.keys.Left):
+			m.activePanel = 2
+		case key.Matches(msg, m.keys.Up):
+			if m.linkIndex > 0 {
+				m.linkIndex--
+			}
+		case key.Matches(msg, m.keys.Down):
+			if m.linkIndex < len(m.links)-1 {
+				m.linkIndex++
+			}
+		case key.Matches(msg, m.keys.Enter):
+			m.followLink()
+		case key.Matches(msg, m.keys.Back):
+			m.goBack()
+		case key.Matches(msg, m.keys.Forward):
+			m.goForward()
+		case key.Matches(msg, m.keys.Favorite):
+			m.toggleFavorite()
+		case key.Matches(msg, m.keys.Export):
+			m.exportCommands()
+		}
+		return m, nil
 	}
 
 	return m, cmd
@@ -296,8 +376,11 @@ func (m Model) renderCategoryPanel() string {
 	}
 
 	title := lipgloss.NewStyle().Bold(true).Render("📁 分类")
+	if m.favoriteMode {
+		title = lipgloss.NewStyle().Bold(true).Render("⭐ 收藏夹")
+	}
 
-	if len(m.categories) == 0 {
+	if !m.favoriteMode && len(m.categories) == 0 {
 		return style.Render(title + "\n\n无数据")
 	}
 
@@ -339,6 +422,10 @@ func (m Model) renderDetailPanel() string {
 		Width(panelWidth).
 		Height(panelHeight)
 
+	if m.activePanel == 3 {
+		style = style.BorderForeground(lipgloss.Color("170"))
+	}
+
 	title := lipgloss.NewStyle().Bold(true).Render("📖 详情")
 
 	if m.selectedCmd == nil {
@@ -357,6 +444,12 @@ func (m Model) renderStatusBar() string {
 
 	totalCmds := m.commandService.Count()
 	status := fmt.Sprintf("总命令数: %d | 当前分类: %d 个命令", totalCmds, len(m.commands))
+	if host := m.commandService.Host(); host != nil {
+		status += fmt.Sprintf(" | 平台: %s", host)
+	}
+	if m.statusMsg != "" {
+		status += " | " + m.statusMsg
+	}
 
 	return style(status)
 }
@@ -367,25 +460,92 @@ func (m Model) renderHelpBar() string {
 		Foreground(lipgloss.Color("241")).
 		Render
 
-	help := "tab:切换 /:搜索 f:收藏 e:导出 q:退出"
+	help := "tab:切换 /:搜索 f:收藏 F:收藏夹 e:导出 q:退出"
+	if m.activePanel == 3 {
+		help = "↑/↓:选择关联命令 enter:跳转 [:后退 ]:前进 ←:返回列表 f:收藏 e:导出 q:退出"
+	}
 	return style(help)
 }
 
 // formatCommandDetail 格式化命令详情
 func (m Model) formatCommandDetail() string {
 	cmd := m.selectedCmd
+	current := ""
+	if host := m.commandService.Host(); host != nil {
+		current = host.OS
+		cmd = cmd.ForPlatform(current)
+	}
 
 	detail := fmt.Sprintf("名称: %s\n\n", cmd.Name)
 	detail += fmt.Sprintf("描述: %s\n\n", cmd.Description)
 
+	if len(cmd.SaferAlternatives) > 0 {
+		warn := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("208"))
+		detail += warn.Render(fmt.Sprintf("风险: %s  更安全的做法:", cmd.GetRiskLevel())) + "\n"
+		for _, alt := range cmd.SaferAlternatives {
+			detail += fmt.Sprintf("  • %s\n", alt.Description)
+			switch {
+			case alt.Example != "":
+				detail += fmt.Sprintf("    %s\n", alt.Example)
+			case alt.Flags != "":
+				detail += fmt.Sprintf("    %s\n", alt.Flags)
+			case alt.Command != "":
+				detail += fmt.Sprintf("    → %s\n", alt.Command)
+			}
+		}
+		detail += "\n"
+	}
+
+	if cmd.DryRun != nil {
+		detail += fmt.Sprintf("预演选项: %s\n\n", cmd.DryRun.Flag)
+	}
+
+	if m.configService != nil {
+		if fav := m.configService.FindFavorite(cmd.Name); fav != nil {
+			detail += "⭐ 已收藏"
+			for _, tag := range fav.Tags {
+				detail += " #" + tag
+			}
+			for _, collection := range fav.Collections {
+				detail += " @" + collection
+			}
+			detail += "\n"
+			if fav.Note != "" {
+				detail += fmt.Sprintf("备注: %s\n", fav.Note)
+			}
+			detail += "\n"
+		}
+	}
+
 	if len(cmd.Usage) > 0 {
-		detail += "用法:\n"
+		if _, ok := cmd.PlatformOverrides[current]; ok {
+			detail += fmt.Sprintf("用法 (%s):\n", current)
+		} else {
+			detail += "用法:\n"
+		}
 		for _, u := range cmd.Usage {
 			detail += fmt.Sprintf("  %s\n", u)
 		}
+		for _, platform := range cmd.OverridePlatforms() {
+			if platform == current {
+				continue
+			}
+			for _, u := range cmd.PlatformOverrides[platform].Usage {
+				detail += fmt.Sprintf("  [%s] %s\n", platform, u)
+			}
+		}
 		detail += "\n"
 	}
 
+	if m.configService != nil {
+		if snippets := m.configService.SnippetsFor(cmd.Name); len(snippets) > 0 {
+			detail += "我的片段:\n"
+			for _, snippet := range snippets {
+				detail += fmt.Sprintf("  %s\n  %s\n\n", snippet.Command, snippet.Description)
+			}
+		}
+	}
+
 	if len(cmd.Examples) > 0 {
 		detail += "示例:\n"
 		for i, ex := range cmd.Examples {
@@ -396,6 +556,18 @@ func (m Model) formatCommandDetail() string {
 		}
 	}
 
+	if len(m.links) > 0 {
+		detail += "关联命令:\n"
+		highlight := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("170"))
+		for i, link := range m.links {
+			line := fmt.Sprintf("  [%s] %s", link.Kind.Label(), link.Other(cmd.Name))
+			if m.activePanel == 3 && i == m.linkIndex {
+				line = highlight.Render("▸" + line[1:])
+			}
+			detail += line + "\n"
+		}
+	}
+
 	return detail
 }
 
@@ -432,7 +604,24 @@ func (m *Model) performSearch() {
 		return
 	}
 
-	results := m.commandService.Search(query)
+	results := m.commandService.ForHost(m.commandService.Search(query))
+
+	// 命中个人片段的来源命令也加入结果
+	if m.configService != nil {
+		found := make(map[string]bool, len(results))
+		for _, cmd := range results {
+			found[cmd.Name] = true
+		}
+		for _, snippet := range m.configService.SearchSnippets(query) {
+			if found[snippet.CommandName] {
+				continue
+			}
+			if cmd, err := m.commandService.GetCommand(snippet.CommandName); err == nil {
+				results = append(results, cmd)
+				found[cmd.Name] = true
+			}
+		}
+	}
 	m.commands = results
 
 	// 更新命令列表
@@ -458,7 +647,7 @@ func (m *Model) loadCategoryCommands() {
 	}
 
 	category := m.categories[selectedIdx]
-	cmds := m.commandService.GetByCategory(category)
+	cmds := m.rankByFrecency(m.commandService.ForHost(m.commandService.GetByCategory(category)))
 	m.commands = cmds
 
 	// 更新命令列表
@@ -472,6 +661,21 @@ func (m *Model) loadCategoryCommands() {
 	m.commandList.SetItems(items)
 }
 
+// rankByFrecency 按个人 frecency 分数排序，未查看过的命令保持原有顺序
+func (m *Model) rankByFrecency(cmds []*model.Command) []*model.Command {
+	if m.configService == nil || !m.commandService.Personalized() {
+		return cmds
+	}
+
+	scores := m.configService.Frecency()
+	ranked := make([]*model.Command, len(cmds))
+	copy(ranked, cmds)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return scores[ranked[i].Name] > scores[ranked[j].Name]
+	})
+	return ranked
+}
+
 // loadCommandDetail 加载命令详情
 func (m *Model) loadCommandDetail() {
 	if len(m.commands) == 0 {
@@ -483,12 +687,66 @@ func (m *Model) loadCommandDetail() {
 		return
 	}
 
-	m.selectedCmd = m.commands[selectedIdx]
+	// 从列表选择命令开始新的浏览
+	m.back = nil
+	m.forward = nil
+	m.showCommand(m.commands[selectedIdx])
+}
+
+// showCommand 在详情面板显示命令并加载其关联命令
+func (m *Model) showCommand(cmd *model.Command) {
+	m.selectedCmd = cmd
+	m.links = nil
+	m.linkIndex = 0
+	if graph, err := m.commandService.Neighbors(cmd.Name, 1); err == nil {
+		m.links = graph.EdgesOf(cmd.Name)
+	}
 
 	// 添加到历史记录
 	if m.configService != nil {
-		m.configService.AddHistory(m.selectedCmd.Name, m.selectedCmd.Category)
+		m.configService.AddHistory(cmd.Name, cmd.Category)
+	}
+}
+
+// followLink 跳转到选中的关联命令
+func (m *Model) followLink() {
+	if m.selectedCmd == nil || m.linkIndex >= len(m.links) {
+		return
 	}
+
+	name := m.links[m.linkIndex].Other(m.selectedCmd.Name)
+	cmd, err := m.commandService.GetCommand(name)
+	if err != nil {
+		return
+	}
+
+	m.back = append(m.back, m.selectedCmd)
+	m.forward = nil
+	m.showCommand(cmd)
+}
+
+// goBack 回到上一个查看的命令
+func (m *Model) goBack() {
+	if len(m.back) == 0 {
+		return
+	}
+
+	prev := m.back[len(m.back)-1]
+	m.back = m.back[:len(m.back)-1]
+	m.forward = append(m.forward, m.selectedCmd)
+	m.showCommand(prev)
+}
+
+// goForward 前进到后退前查看的命令
+func (m *Model) goForward() {
+	if len(m.forward) == 0 {
+		return
+	}
+
+	next := m.forward[len(m.forward)-1]
+	m.forward = m.forward[:len(m.forward)-1]
+	m.back = append(m.back, m.selectedCmd)
+	m.showCommand(next)
 }
 
 // toggleFavorite 切换收藏状态
@@ -502,6 +760,118 @@ func (m *Model) toggleFavorite() {
 	} else {
 		m.configService.AddFavorite(m.selectedCmd.Name, m.selectedCmd.Category, "")
 	}
+
+	if m.favoriteMode {
+		m.refreshFavoriteFilters()
+	}
+}
+
+// exportCommands 按导出配置导出当前命令列表，在详情面板中只导出当前命令
+func (m *Model) exportCommands() {
+	commands := m.commands
+	if m.activePanel == 3 && m.selectedCmd != nil {
+		commands = []*model.Command{m.selectedCmd}
+	}
+	if len(commands) == 0 {
+		m.statusMsg = "没有可导出的命令"
+		return
+	}
+
+	cfg := model.DefaultConfig()
+	if m.configService != nil {
+		cfg = m.configService.GetConfig()
+	}
+	opts := export.DefaultOptions()
+	opts.Locale = cfg.Language
+
+	exporter, err := export.Get(cfg.Export.DefaultFormat)
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("导出失败: %v", err)
+		return
+	}
+
+	path := cfg.Export.OutputPath("commands", exporter.Extension(), time.Now())
+	if err := export.ExportToFile(exporter, commands, path, opts); err != nil {
+		m.statusMsg = fmt.Sprintf("导出失败: %v", err)
+		return
+	}
+	m.statusMsg = fmt.Sprintf("已导出 %d 个命令到 %s", len(commands), path)
+}
+
+// favoriteFilter 收藏夹中的筛选项，tag 和 collection 都为空表示全部收藏
+type favoriteFilter struct {
+	label      string
+	tag        string
+	collection string
+}
+
+// toggleFavoritePanel 在分类和收藏夹之间切换左侧面板
+func (m *Model) toggleFavoritePanel() {
+	if m.configService == nil {
+		return
+	}
+
+	m.favoriteMode = !m.favoriteMode
+	if m.favoriteMode {
+		m.refreshFavoriteFilters()
+	} else {
+		items := make([]list.Item, len(m.categories))
+		for i, cat := range m.categories {
+			items[i] = listItem{title: cat, desc: ""}
+		}
+		m.categoryList.SetItems(items)
+	}
+	m.categoryList.Select(0)
+
+	m.activePanel = 1
+	m.updateFocus()
+}
+
+// refreshFavoriteFilters 按当前收藏的标签和收藏集重建筛选项
+func (m *Model) refreshFavoriteFilters() {
+	filters := []favoriteFilter{{label: "全部收藏"}}
+	for _, tag := range m.configService.FavoriteTags() {
+		filters = append(filters, favoriteFilter{label: "#" + tag, tag: tag})
+	}
+	for _, collection := range m.configService.FavoriteCollections() {
+		filters = append(filters, favoriteFilter{label: "@" + collection, collection: collection})
+	}
+	m.favoriteFilters = filters
+
+	items := make([]list.Item, len(filters))
+	for i, f := range filters {
+		items[i] = listItem{title: f.label, desc: ""}
+	}
+	m.categoryList.SetItems(items)
+}
+
+// loadFavoriteCommands 加载所选标签或收藏集下的收藏命令
+func (m *Model) loadFavoriteCommands() {
+	selectedIdx := m.categoryList.Index()
+	if selectedIdx < 0 || selectedIdx >= len(m.favoriteFilters) {
+		return
+	}
+
+	filter := m.favoriteFilters[selectedIdx]
+	favorites := m.configService.FilterFavorites(filter.tag, filter.collection)
+
+	var cmds []*model.Command
+	var items []list.Item
+	for _, fav := range favorites {
+		cmd, err := m.commandService.GetCommand(fav.CommandName)
+		if err != nil {
+			continue
+		}
+		desc := cmd.Description
+		if fav.Note != "" {
+			desc = "📝 " + fav.Note
+		}
+		cmds = append(cmds, cmd)
+		items = append(items, listItem{title: cmd.Name, desc: desc})
+	}
+
+	m.commands = cmds
+	m.commandList.SetItems(items)
 }
 
 // updateFocus 更新焦点
@@ -0,0 +1,24 @@
+package executor
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ErrEmptyCommand 命令行为空
+type ErrEmptyCommand struct{}
+
+func (e ErrEmptyCommand) Error() string {
+	return "refusing to run an empty command"
+}
+
+// ErrUnfilledPlaceholder 命令行中仍有未填充的参数
+type ErrUnfilledPlaceholder struct {
+	Command      string
+	Placeholders []string
+}
+
+func (e ErrUnfilledPlaceholder) Error() string {
+	return fmt.Sprintf("refusing to run command with unfilled parameters %s: %s",
+		strings.Join(e.Placeholders, ", "), e.Command)
+}
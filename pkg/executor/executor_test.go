@@ -0,0 +1,143 @@
+package executor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func skipOnWindows(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("tests use POSIX shell commands")
+	}
+}
+
+func TestRunnerRun(t *testing.T) {
+	skipOnWindows(t)
+
+	var live bytes.Buffer
+	runner := NewRunner()
+	runner.Stdout = &live
+
+	result, err := runner.Run(context.Background(), "echo hello; echo oops 1>&2; exit 3")
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if result.Stdout != "hello\n" || result.Stderr != "oops\n" {
+		t.Errorf("Unexpected output: stdout=%q stderr=%q", result.Stdout, result.Stderr)
+	}
+	if result.ExitCode != 3 || result.Success() {
+		t.Errorf("Expected exit code 3, got %d", result.ExitCode)
+	}
+	if live.String() != "hello\n" {
+		t.Errorf("Expected live output to be streamed, got %q", live.String())
+	}
+	if result.Finished.Before(result.Started) {
+		t.Error("Finished before Started")
+	}
+}
+
+func TestRunnerGuards(t *testing.T) {
+	runner := NewRunner()
+
+	if _, err := runner.Run(context.Background(), "  "); !errors.As(err, &ErrEmptyCommand{}) {
+		t.Errorf("Expected ErrEmptyCommand, got %v", err)
+	}
+
+	var unfilled ErrUnfilledPlaceholder
+	_, err := runner.Run(context.Background(), "kubectl logs {{pod}} -n prod")
+	if !errors.As(err, &unfilled) || unfilled.Placeholders[0] != "{{pod}}" {
+		t.Errorf("Expected ErrUnfilledPlaceholder, got %v", err)
+	}
+}
+
+func TestRunnerTimeoutAndTruncate(t *testing.T) {
+	skipOnWindows(t)
+
+	runner := NewRunner()
+	runner.Timeout = 100 * time.Millisecond
+
+	result, err := runner.Run(context.Background(), "sleep 5")
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if !result.TimedOut || result.ExitCode != -1 {
+		t.Errorf("Expected timeout, got %+v", result)
+	}
+
+	runner = NewRunner()
+	runner.MaxOutput = 4
+	result, err = runner.Run(context.Background(), "echo 0123456789")
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if result.Stdout != "0123" || !result.Truncated {
+		t.Errorf("Expected truncated output, got %q", result.Stdout)
+	}
+}
+
+func TestTranscriptSave(t *testing.T) {
+	transcript := NewTranscript("k8s-pod-crashloop", "Pod 反复重启排查", map[string]string{"namespace": "payments"})
+	transcript.Started = time.Date(2026, 10, 19, 15, 4, 5, 0, time.Local)
+
+	transcript.Record(StepRecord{
+		Index:   1,
+		Title:   "查看状态",
+		Command: "kubectl get",
+		Risk:    "low",
+		Run:     "kubectl get pods -n payments",
+		Status:  StepExecuted,
+		Result: &Result{
+			Command:  "kubectl get pods -n payments",
+			Stdout:   "api-0   0/1   CrashLoopBackOff\n```\n",
+			ExitCode: 0,
+			Duration: 1500 * time.Millisecond,
+		},
+	})
+	transcript.Record(StepRecord{
+		Index:  2,
+		Title:  "回滚",
+		Risk:   "high",
+		Run:    "kubectl rollout undo deployment/api -n payments",
+		Status: StepDeclined,
+		Note:   "未确认高风险操作",
+	})
+	transcript.Finish()
+
+	mdPath, jsonPath, err := transcript.Save(t.TempDir())
+	if err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if !strings.HasSuffix(mdPath, "runbook-k8s-pod-crashloop-20261019-150405.md") {
+		t.Errorf("Unexpected transcript path: %s", mdPath)
+	}
+
+	md, err := os.ReadFile(mdPath)
+	if err != nil {
+		t.Fatalf("Failed to read markdown: %v", err)
+	}
+	for _, want := range []string{"`namespace` = `payments`", "CrashLoopBackOff", "````", "declined", "1.5s"} {
+		if !strings.Contains(string(md), want) {
+			t.Errorf("Markdown transcript missing %q", want)
+		}
+	}
+
+	data, err := os.ReadFile(jsonPath)
+	if err != nil {
+		t.Fatalf("Failed to read JSON: %v", err)
+	}
+	var decoded Transcript
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Invalid JSON transcript: %v", err)
+	}
+	if len(decoded.Steps) != 2 || decoded.Steps[0].Result.Duration != 1500*time.Millisecond {
+		t.Errorf("Unexpected decoded transcript: %+v", decoded.Steps)
+	}
+}
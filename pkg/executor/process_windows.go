@@ -0,0 +1,16 @@
+//go:build windows
+
+package executor
+
+import "os/exec"
+
+// setProcessGroup Windows 下不需要额外设置
+func setProcessGroup(cmd *exec.Cmd) {}
+
+// killProcessGroup 终止命令进程
+func killProcessGroup(cmd *exec.Cmd) {
+	if cmd.Process == nil {
+		return
+	}
+	_ = cmd.Process.Kill()
+}
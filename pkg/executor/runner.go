@@ -0,0 +1,176 @@
+// Package executor 通过带防护的 os/exec 封装执行命令行，并记录执行过程
+package executor
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// DefaultMaxOutput 每个输出流默认最多保留的字节数
+const DefaultMaxOutput = 1 << 20
+
+// unfilledPattern 匹配未填充的 {{参数}} 占位符
+var unfilledPattern = regexp.MustCompile(`\{\{\s*[\w.-]+\s*\}\}`)
+
+// Result 一次命令执行的结果
+type Result struct {
+	Command   string        `json:"command"`             // 执行的命令行
+	Stdout    string        `json:"stdout"`              // 标准输出
+	Stderr    string        `json:"stderr"`              // 标准错误
+	ExitCode  int           `json:"exit_code"`           // 退出码，未能启动或超时为 -1
+	Started   time.Time     `json:"started"`             // 开始时间
+	Finished  time.Time     `json:"finished"`            // 结束时间
+	Duration  time.Duration `json:"duration_ns"`         // 耗时
+	TimedOut  bool          `json:"timed_out,omitempty"` // 是否超时被终止
+	Truncated bool          `json:"truncated,omitempty"` // 输出是否超过上限被截断
+	Error     string        `json:"error,omitempty"`     // 启动失败等错误
+}
+
+// Success 命令是否正常退出且退出码为 0
+func (r *Result) Success() bool {
+	return r.ExitCode == 0 && r.Error == "" && !r.TimedOut
+}
+
+// Runner 带防护的命令执行器
+//
+// 执行前拒绝空命令和仍含 {{参数}} 占位符的命令；执行时限制超时和输出大小。
+type Runner struct {
+	Shell     []string      // 执行命令行的 shell，默认 sh -c（Windows 为 cmd /C）
+	Dir       string        // 工作目录，空表示当前目录
+	Timeout   time.Duration // 单条命令超时，0 表示不限制
+	MaxOutput int           // 每个输出流最多保留的字节数
+	Stdout    io.Writer     // 同时实时输出到这里（可选）
+	Stderr    io.Writer     // 同时实时输出到这里（可选）
+}
+
+// NewRunner 创建执行器
+func NewRunner() *Runner {
+	shell := []string{"sh", "-c"}
+	if runtime.GOOS == "windows" {
+		shell = []string{"cmd", "/C"}
+	}
+	return &Runner{
+		Shell:     shell,
+		MaxOutput: DefaultMaxOutput,
+	}
+}
+
+// Check 检查命令行是否允许执行
+func (r *Runner) Check(commandLine string) error {
+	if strings.TrimSpace(commandLine) == "" {
+		return ErrEmptyCommand{}
+	}
+	if placeholders := unfilledPattern.FindAllString(commandLine, -1); len(placeholders) > 0 {
+		return ErrUnfilledPlaceholder{Command: commandLine, Placeholders: placeholders}
+	}
+	return nil
+}
+
+// Run 执行命令行；未通过检查时不执行并返回错误，命令本身失败记录在 Result 中
+func (r *Runner) Run(ctx context.Context, commandLine string) (*Result, error) {
+	if err := r.Check(commandLine); err != nil {
+		return nil, err
+	}
+	if len(r.Shell) == 0 {
+		return nil, fmt.Errorf("no shell configured")
+	}
+
+	if r.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.Timeout)
+		defer cancel()
+	}
+
+	args := append(append([]string{}, r.Shell[1:]...), commandLine)
+	cmd := exec.Command(r.Shell[0], args...)
+	cmd.Dir = r.Dir
+	setProcessGroup(cmd)
+
+	stdout := &limitedBuffer{limit: r.MaxOutput}
+	stderr := &limitedBuffer{limit: r.MaxOutput}
+	cmd.Stdout = teeWriter(stdout, r.Stdout)
+	cmd.Stderr = teeWriter(stderr, r.Stderr)
+
+	result := &Result{Command: commandLine, Started: time.Now()}
+	err := cmd.Start()
+	if err == nil {
+		// 超时或取消时终止整个进程组，避免 shell 的子进程继续占用输出管道
+		done := make(chan struct{})
+		go func() {
+			select {
+			case <-ctx.Done():
+				killProcessGroup(cmd)
+			case <-done:
+			}
+		}()
+		err = cmd.Wait()
+		close(done)
+	}
+	result.Finished = time.Now()
+	result.Duration = result.Finished.Sub(result.Started)
+	result.Stdout = stdout.String()
+	result.Stderr = stderr.String()
+	result.Truncated = stdout.truncated || stderr.truncated
+
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		result.ExitCode = 0
+	case ctx.Err() != nil:
+		result.ExitCode = -1
+		result.TimedOut = errors.Is(ctx.Err(), context.DeadlineExceeded)
+		result.Error = ctx.Err().Error()
+	case errors.As(err, &exitErr):
+		result.ExitCode = exitErr.ExitCode()
+	default:
+		result.ExitCode = -1
+		result.Error = err.Error()
+	}
+
+	return result, nil
+}
+
+// teeWriter 同时写入缓冲区和可选的实时输出
+func teeWriter(buf *limitedBuffer, live io.Writer) io.Writer {
+	if live == nil {
+		return buf
+	}
+	return io.MultiWriter(buf, live)
+}
+
+// limitedBuffer 只保留前 limit 字节的缓冲区，超出部分丢弃但不报错
+type limitedBuffer struct {
+	buf       bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.limit <= 0 {
+		return b.buf.Write(p)
+	}
+
+	remaining := b.limit - b.buf.Len()
+	if remaining <= 0 {
+		b.truncated = b.truncated || len(p) > 0
+		return len(p), nil
+	}
+	if len(p) > remaining {
+		b.buf.Write(p[:remaining])
+		b.truncated = true
+		return len(p), nil
+	}
+	return b.buf.Write(p)
+}
+
+func (b *limitedBuffer) String() string {
+	return b.buf.String()
+}
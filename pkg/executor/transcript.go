@@ -0,0 +1,191 @@
+package executor
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// 步骤状态
+const (
+	StepExecuted = "executed" // 已执行
+	StepSkipped  = "skipped"  // 用户跳过
+	StepDeclined = "declined" // 高风险步骤未确认
+	StepBlocked  = "blocked"  // 未通过执行前检查
+)
+
+// StepRecord 一个步骤的执行记录
+type StepRecord struct {
+	Index   int       `json:"index"`            // 步骤序号（从1开始）
+	Title   string    `json:"title"`            // 步骤标题
+	Command string    `json:"command"`          // 引用的命令名称
+	Risk    string    `json:"risk"`             // 风险级别
+	Run     string    `json:"run"`              // 填充参数后的命令行
+	Status  string    `json:"status"`           // 状态
+	Note    string    `json:"note,omitempty"`   // 跳过或拦截的原因
+	Result  *Result   `json:"result,omitempty"` // 执行结果
+	Decided time.Time `json:"decided"`          // 记录时间
+}
+
+// Transcript 一次运行手册执行的完整记录，可附到事故复盘中
+type Transcript struct {
+	RunbookID string            `json:"runbook_id"`
+	Title     string            `json:"title"`
+	Params    map[string]string `json:"params,omitempty"`
+	Host      string            `json:"host,omitempty"`
+	User      string            `json:"user,omitempty"`
+	Started   time.Time         `json:"started"`
+	Finished  time.Time         `json:"finished"`
+	Steps     []StepRecord      `json:"steps"`
+}
+
+// NewTranscript 创建执行记录，记录当前主机和用户
+func NewTranscript(runbookID, title string, params map[string]string) *Transcript {
+	host, _ := os.Hostname()
+	user := os.Getenv("USER")
+	if user == "" {
+		user = os.Getenv("USERNAME")
+	}
+	return &Transcript{
+		RunbookID: runbookID,
+		Title:     title,
+		Params:    params,
+		Host:      host,
+		User:      user,
+		Started:   time.Now(),
+	}
+}
+
+// Record 追加一个步骤记录
+func (t *Transcript) Record(step StepRecord) {
+	if step.Decided.IsZero() {
+		step.Decided = time.Now()
+	}
+	t.Steps = append(t.Steps, step)
+	t.Finished = step.Decided
+}
+
+// Finish 标记执行结束
+func (t *Transcript) Finish() {
+	t.Finished = time.Now()
+}
+
+// WriteJSON 以 JSON 格式写出执行记录
+func (t *Transcript) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(t); err != nil {
+		return fmt.Errorf("failed to encode transcript: %w", err)
+	}
+	return nil
+}
+
+// WriteMarkdown 以 Markdown 格式写出执行记录
+func (t *Transcript) WriteMarkdown(w io.Writer) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# 运行手册执行记录: %s\n\n", t.Title)
+	fmt.Fprintf(&b, "- **运行手册**: `%s`\n", t.RunbookID)
+	fmt.Fprintf(&b, "- **开始时间**: %s\n", t.Started.Format(time.RFC3339))
+	fmt.Fprintf(&b, "- **结束时间**: %s\n", t.Finished.Format(time.RFC3339))
+	if t.Host != "" {
+		fmt.Fprintf(&b, "- **主机**: %s\n", t.Host)
+	}
+	if t.User != "" {
+		fmt.Fprintf(&b, "- **执行人**: %s\n", t.User)
+	}
+
+	if len(t.Params) > 0 {
+		names := make([]string, 0, len(t.Params))
+		for name := range t.Params {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		b.WriteString("\n## 参数\n\n")
+		for _, name := range names {
+			fmt.Fprintf(&b, "- `%s` = `%s`\n", name, t.Params[name])
+		}
+	}
+
+	b.WriteString("\n## 步骤\n")
+	for _, step := range t.Steps {
+		fmt.Fprintf(&b, "\n### %d. %s\n\n", step.Index, step.Title)
+		fmt.Fprintf(&b, "- **命令**: %s（风险: %s）\n", step.Command, step.Risk)
+		fmt.Fprintf(&b, "- **状态**: %s\n", step.Status)
+		if step.Note != "" {
+			fmt.Fprintf(&b, "- **说明**: %s\n", step.Note)
+		}
+		fmt.Fprintf(&b, "\n```\n$ %s\n```\n", step.Run)
+
+		if r := step.Result; r != nil {
+			fmt.Fprintf(&b, "\n- **退出码**: %d\n", r.ExitCode)
+			fmt.Fprintf(&b, "- **开始**: %s\n", r.Started.Format(time.RFC3339))
+			fmt.Fprintf(&b, "- **耗时**: %s\n", r.Duration.Round(time.Millisecond))
+			if r.TimedOut {
+				b.WriteString("- **超时**: 是\n")
+			}
+			if r.Error != "" {
+				fmt.Fprintf(&b, "- **错误**: %s\n", r.Error)
+			}
+			writeOutputBlock(&b, "标准输出", r.Stdout)
+			writeOutputBlock(&b, "标准错误", r.Stderr)
+			if r.Truncated {
+				b.WriteString("\n> 输出超过上限，已截断\n")
+			}
+		}
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("failed to write transcript: %w", err)
+	}
+	return nil
+}
+
+// writeOutputBlock 写出一段命令输出，内容中的 ``` 会加长围栏避免提前闭合
+func writeOutputBlock(b *strings.Builder, title, output string) {
+	if output == "" {
+		return
+	}
+
+	fence := "```"
+	for strings.Contains(output, fence) {
+		fence += "`"
+	}
+	fmt.Fprintf(b, "\n**%s**:\n\n%s\n%s\n%s\n", title, fence, strings.TrimRight(output, "\n"), fence)
+}
+
+// Save 把执行记录写到目录下带时间戳的 .md 和 .json 文件，返回两个文件路径
+func (t *Transcript) Save(dir string) (string, string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", "", fmt.Errorf("failed to create transcript directory: %w", err)
+	}
+
+	base := filepath.Join(dir, fmt.Sprintf("runbook-%s-%s", t.RunbookID, t.Started.Format("20060102-150405")))
+	mdPath, jsonPath := base+".md", base+".json"
+
+	if err := writeFile(mdPath, t.WriteMarkdown); err != nil {
+		return "", "", err
+	}
+	if err := writeFile(jsonPath, t.WriteJSON); err != nil {
+		return "", "", err
+	}
+
+	return mdPath, jsonPath, nil
+}
+
+// writeFile 创建文件并用 write 写入内容
+func writeFile(path string, write func(io.Writer) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	return write(f)
+}
@@ -0,0 +1,143 @@
+package export
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+
+	"github.com/cmd4coder/cmd4coder/internal/model"
+)
+
+func init() {
+	Register("anki", AnkiExporter{})
+}
+
+// AnkiExporter 导出为 Anki 可导入的 TSV 卡片组
+//
+// 文件头声明分隔符、牌组和各列含义，Anki 2.1.55 及以上通过“文件 > 导入”直接识别。
+// 每个示例一张卡片，正面为示例说明，背面为示例命令、注意事项和风险；
+// 标签由分类和风险级别生成。guid 列由 ankiGUIDPrefix、命令名和示例序号生成，
+// 重复导入时更新而不是新增卡片，也不会与其他牌组的卡片冲突。
+type AnkiExporter struct{}
+
+// Extension 实现 Exporter
+func (e AnkiExporter) Extension() string {
+	return "tsv"
+}
+
+// ankiGUIDPrefix guid 命名空间，避免与 Anki 中其他来源的卡片 guid 冲突
+const ankiGUIDPrefix = "cmd4coder:"
+
+// ankiCard 一张卡片
+type ankiCard struct {
+	GUID  string
+	Front string
+	Back  string
+	Tags  []string
+}
+
+// Export 实现 Exporter
+func (e AnkiExporter) Export(w io.Writer, commands []*model.Command, opts Options) error {
+	l := labelsFor(opts.Locale)
+	deck := opts.Title
+	if deck == "" {
+		deck = l.Title
+	}
+
+	var b strings.Builder
+	b.WriteString("#separator:tab\n")
+	b.WriteString("#html:true\n")
+	b.WriteString("#notetype:Basic\n")
+	fmt.Fprintf(&b, "#deck:%s\n", ankiField(deck))
+	b.WriteString("#guid column:1\n")
+	b.WriteString("#tags column:4\n")
+
+	for _, cmd := range commands {
+		for _, card := range ankiCards(cmd, l, opts) {
+			fmt.Fprintf(&b, "%s\t%s\t%s\t%s\n", ankiField(card.GUID), card.Front, card.Back, ankiField(strings.Join(card.Tags, " ")))
+		}
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("failed to write deck: %w", err)
+	}
+	return nil
+}
+
+// ankiCards 生成命令的卡片；没有示例的命令以描述和用法生成一张卡片
+func ankiCards(cmd *model.Command, l labels, opts Options) []ankiCard {
+	tags := ankiTags(cmd, opts)
+
+	// 注意事项和风险附在每张卡片背面
+	var extra strings.Builder
+	if len(cmd.Notes) > 0 {
+		fmt.Fprintf(&extra, "<br><b>%s</b><ul>", html.EscapeString(l.Notes))
+		for _, note := range cmd.Notes {
+			fmt.Fprintf(&extra, "<li>%s</li>", ankiHTML(note))
+		}
+		extra.WriteString("</ul>")
+	}
+	if opts.IncludeRisks && len(cmd.Risks) > 0 {
+		fmt.Fprintf(&extra, "<br><b>%s</b><ul>", html.EscapeString(l.Risks))
+		for _, risk := range cmd.Risks {
+			fmt.Fprintf(&extra, "<li>[%s] %s</li>", html.EscapeString(l.RiskLevels[risk.Level]), ankiHTML(risk.Description))
+		}
+		extra.WriteString("</ul>")
+	}
+
+	back := func(command string) string {
+		return fmt.Sprintf("<code>%s</code><br><small>%s</small>%s", ankiHTML(command), html.EscapeString(cmd.Name), extra.String())
+	}
+
+	if len(cmd.Examples) == 0 {
+		command := cmd.Name
+		if len(cmd.Usage) > 0 {
+			command = cmd.Usage[0]
+		}
+		return []ankiCard{{GUID: ankiGUIDPrefix + cmd.Name, Front: ankiHTML(cmd.Description), Back: back(command), Tags: tags}}
+	}
+
+	cards := make([]ankiCard, 0, len(cmd.Examples))
+	for i, ex := range cmd.Examples {
+		front := ex.Description
+		if front == "" {
+			front = cmd.Description
+		}
+		cards = append(cards, ankiCard{
+			GUID:  fmt.Sprintf("%s%s#%d", ankiGUIDPrefix, cmd.Name, i+1),
+			Front: ankiHTML(front),
+			Back:  back(ex.Command),
+			Tags:  tags,
+		})
+	}
+	return cards
+}
+
+// ankiTags 分类转为层级标签（以 :: 分隔），加上 cmd4coder 标签；
+// 导出风险且命令有风险记录时再加风险级别标签
+func ankiTags(cmd *model.Command, opts Options) []string {
+	tags := []string{"cmd4coder"}
+	if cmd.Category != "" {
+		parts := strings.Split(cmd.Category, "/")
+		for i, part := range parts {
+			parts[i] = strings.Join(strings.Fields(part), "_")
+		}
+		tags = append(tags, strings.Join(parts, "::"))
+	}
+	if opts.IncludeRisks && len(cmd.Risks) > 0 {
+		tags = append(tags, "risk::"+string(cmd.GetHighestRisk()))
+	}
+	return tags
+}
+
+// ankiHTML 转义为 HTML 字段，换行转为 <br>，制表符替换为空格
+func ankiHTML(s string) string {
+	s = html.EscapeString(strings.TrimSpace(s))
+	return strings.NewReplacer("\t", " ", "\r\n", "<br>", "\n", "<br>").Replace(s)
+}
+
+// ankiField 纯文本字段，去掉会破坏 TSV 结构的字符
+func ankiField(s string) string {
+	return strings.NewReplacer("\t", " ", "\n", " ", "\r", " ").Replace(s)
+}
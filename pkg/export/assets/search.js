@@ -0,0 +1,78 @@
+// cmd4coder 离线搜索：读取 search-index.js 预先生成的索引，不依赖网络请求
+(function () {
+    'use strict';
+
+    var index = window.CMD4CODER_INDEX || [];
+    var input = document.getElementById('search');
+    var results = document.getElementById('results');
+    if (!input || !results) {
+        return;
+    }
+
+    // 名称前缀匹配优先，其次名称包含，最后描述和分类包含
+    function score(entry, terms) {
+        var name = entry.name.toLowerCase();
+        var text = name + ' ' + entry.description.toLowerCase() + ' ' + entry.category.toLowerCase();
+        var total = 0;
+        for (var i = 0; i < terms.length; i++) {
+            var term = terms[i];
+            if (text.indexOf(term) === -1) {
+                return 0;
+            }
+            if (name.indexOf(term) === 0) {
+                total += 3;
+            } else if (name.indexOf(term) !== -1) {
+                total += 2;
+            } else {
+                total += 1;
+            }
+        }
+        return total;
+    }
+
+    function render(query) {
+        var terms = query.toLowerCase().split(/\s+/).filter(Boolean);
+        results.innerHTML = '';
+        if (terms.length === 0) {
+            results.classList.add('hidden');
+            return;
+        }
+
+        var matches = [];
+        index.forEach(function (entry) {
+            var s = score(entry, terms);
+            if (s > 0) {
+                matches.push({ entry: entry, score: s });
+            }
+        });
+        matches.sort(function (a, b) {
+            return b.score - a.score || a.entry.name.localeCompare(b.entry.name);
+        });
+
+        results.classList.remove('hidden');
+        if (matches.length === 0) {
+            var empty = document.createElement('li');
+            empty.textContent = results.getAttribute('data-empty');
+            results.appendChild(empty);
+            return;
+        }
+
+        matches.slice(0, 50).forEach(function (m) {
+            var li = document.createElement('li');
+            var a = document.createElement('a');
+            a.href = m.entry.url;
+            a.textContent = m.entry.name;
+            var desc = document.createElement('span');
+            desc.className = 'desc';
+            desc.textContent = m.entry.description;
+            li.appendChild(a);
+            li.appendChild(desc);
+            results.appendChild(li);
+        });
+    }
+
+    input.addEventListener('input', function () {
+        render(input.value);
+    });
+    render(input.value);
+})();
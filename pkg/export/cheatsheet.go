@@ -0,0 +1,339 @@
+package export
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+	"unicode"
+
+	"github.com/cmd4coder/cmd4coder/internal/model"
+)
+
+func init() {
+	Register("cheatsheet", CheatSheetExporter{})
+	Register("cheatsheet-latex", CheatSheetExporter{LaTeX: true})
+}
+
+// 速查表版式：A4 横向三栏，按每栏行数和每行宽度估算篇幅
+const (
+	cheatColumns        = 3
+	cheatLinesPerColumn = 60
+	cheatLineWidth      = 56 // 每行可容纳的半角字符数，全角字符按 2 计
+	cheatMaxExamples    = 2  // 每个命令最多列出的示例数
+)
+
+// CheatSheetExporter 导出为可打印的多栏速查表
+//
+// 默认输出打印优化的 HTML（在浏览器中打印或另存为 PDF）；LaTeX 为 true 时输出
+// xelatex 源文件。Options.PageBudget 大于 0 时先减少示例，仍然放不下再截断命令。
+type CheatSheetExporter struct {
+	LaTeX bool
+}
+
+// Extension 实现 Exporter
+func (e CheatSheetExporter) Extension() string {
+	if e.LaTeX {
+		return "tex"
+	}
+	return "html"
+}
+
+// Layout 实现 PagedExporter
+func (e CheatSheetExporter) Layout(commands []*model.Command, opts Options) (listed, omitted, pages int) {
+	sheet := planCheatSheet(commands, opts)
+	return len(commands) - sheet.Omitted, sheet.Omitted, sheet.Pages
+}
+
+// Export 实现 Exporter
+func (e CheatSheetExporter) Export(w io.Writer, commands []*model.Command, opts Options) error {
+	sheet := planCheatSheet(commands, opts)
+	if e.LaTeX {
+		return writeCheatSheetLaTeX(w, sheet)
+	}
+	return writeCheatSheetHTML(w, sheet)
+}
+
+// cheatSheet 排版后的速查表
+type cheatSheet struct {
+	Title    string
+	Labels   labels
+	Legend   []cheatLegend
+	Sections []cheatSection
+	Omitted  int // 超出页数预算未列出的命令数
+	Pages    int // 预计页数
+}
+
+// cheatLegend 风险标记图例
+type cheatLegend struct {
+	Risk  model.RiskLevel
+	Label string
+}
+
+// Footer 页脚说明，没有命令被截断时为空
+func (s cheatSheet) Footer() string {
+	if s.Omitted == 0 {
+		return ""
+	}
+	return fmt.Sprintf(s.Labels.Omitted, s.Omitted)
+}
+
+// cheatSection 速查表中的一个分类
+type cheatSection struct {
+	Category string
+	Entries  []cheatEntry
+}
+
+// cheatEntry 速查表中的一个命令
+type cheatEntry struct {
+	Name        string
+	Description string
+	Risk        model.RiskLevel
+	Examples    []string
+}
+
+// planCheatSheet 按页数预算安排速查表内容
+func planCheatSheet(commands []*model.Command, opts Options) cheatSheet {
+	l := labelsFor(opts.Locale)
+	title := opts.Title
+	if title == "" {
+		title = l.Title
+	}
+
+	capacity := 0
+	if opts.PageBudget > 0 {
+		capacity = opts.PageBudget * cheatColumns * cheatLinesPerColumn
+	}
+
+	var sheet cheatSheet
+	var lines int
+	for examples := cheatMaxExamples; examples >= 0; examples-- {
+		sheet, lines = layoutCheatSheet(commands, examples, capacity)
+		if capacity == 0 || sheet.Omitted == 0 {
+			break
+		}
+	}
+
+	sheet.Title = title
+	sheet.Labels = l
+	for _, risk := range []model.RiskLevel{model.RiskLevelMedium, model.RiskLevelHigh, model.RiskLevelCritical} {
+		sheet.Legend = append(sheet.Legend, cheatLegend{Risk: risk, Label: l.RiskLevels[risk]})
+	}
+	sheet.Pages = (lines + cheatColumns*cheatLinesPerColumn - 1) / (cheatColumns * cheatLinesPerColumn)
+	return sheet
+}
+
+// layoutCheatSheet 按分类排列命令，capacity 大于 0 时在第一个放不下的命令处截断，其后的命令计入 Omitted
+func layoutCheatSheet(commands []*model.Command, examples, capacity int) (cheatSheet, int) {
+	var sheet cheatSheet
+	lines := 0
+	sectionIndex := make(map[string]int)
+
+	for n, cmd := range commands {
+		entry := cheatEntry{
+			Name:        cmd.Name,
+			Description: firstLine(cmd.Description),
+			Risk:        cmd.GetHighestRisk(),
+		}
+		for i := 0; i < len(cmd.Examples) && i < examples; i++ {
+			entry.Examples = append(entry.Examples, cmd.Examples[i].Command)
+		}
+
+		need := entryLines(entry)
+		i, ok := sectionIndex[cmd.Category]
+		if !ok {
+			need += 2 // 分类标题
+		}
+		if capacity > 0 && lines+need > capacity {
+			sheet.Omitted = len(commands) - n
+			break
+		}
+
+		if !ok {
+			i = len(sheet.Sections)
+			sectionIndex[cmd.Category] = i
+			sheet.Sections = append(sheet.Sections, cheatSection{Category: cmd.Category})
+		}
+		sheet.Sections[i].Entries = append(sheet.Sections[i].Entries, entry)
+		lines += need
+	}
+
+	return sheet, lines
+}
+
+// entryLines 估算一个命令占用的行数
+func entryLines(e cheatEntry) int {
+	lines := wrappedLines(e.Name + "  " + e.Description)
+	for _, ex := range e.Examples {
+		lines += wrappedLines("  " + ex)
+	}
+	return lines
+}
+
+// wrappedLines 估算文本折行后的行数
+func wrappedLines(s string) int {
+	width := 0
+	for _, r := range s {
+		if unicode.Is(unicode.Han, r) || (r >= 0x3000 && r <= 0x303f) || (r >= 0xff00 && r <= 0xffef) {
+			width += 2
+		} else {
+			width++
+		}
+	}
+	return (width + cheatLineWidth - 1) / cheatLineWidth
+}
+
+// firstLine 取描述的第一行
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(s), "\n")
+	return line
+}
+
+// writeCheatSheetHTML 输出打印优化的 HTML
+func writeCheatSheetHTML(w io.Writer, sheet cheatSheet) error {
+	tmpl, err := template.New("cheatsheet").Funcs(template.FuncMap{
+		"marker": riskMarker,
+	}).Parse(cheatSheetTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to parse template: %w", err)
+	}
+	if err := tmpl.Execute(w, sheet); err != nil {
+		return fmt.Errorf("failed to render cheat sheet: %w", err)
+	}
+	return nil
+}
+
+// riskMarker 风险标记，低风险不标记以减少干扰
+func riskMarker(level model.RiskLevel) string {
+	switch level {
+	case model.RiskLevelMedium:
+		return "●"
+	case model.RiskLevelHigh:
+		return "▲"
+	case model.RiskLevelCritical:
+		return "✖"
+	default:
+		return ""
+	}
+}
+
+// cheatSheetTemplate 速查表 HTML 模板
+const cheatSheetTemplate = `<!DOCTYPE html>
+<html lang="{{.Labels.Lang}}">
+<head>
+<meta charset="UTF-8">
+<title>{{.Title}}</title>
+<style>
+@page { size: A4 landscape; margin: 10mm; }
+body { margin: 0; font: 7.5pt/1.3 -apple-system, "Segoe UI", "PingFang SC", "Microsoft YaHei", sans-serif; color: #000; }
+header { display: flex; justify-content: space-between; align-items: baseline; border-bottom: 1px solid #000; margin-bottom: 4px; }
+h1 { font-size: 11pt; margin: 0; }
+.legend { font-size: 7pt; }
+.sheet { column-count: 3; column-gap: 6mm; column-rule: 1px solid #ccc; }
+h2 { font-size: 8.5pt; margin: 4px 0 2px; padding: 1px 3px; background: #000; color: #fff; break-after: avoid; }
+.entry { break-inside: avoid; margin-bottom: 2px; }
+.name { font-weight: 700; font-family: "SFMono-Regular", Consolas, Menlo, monospace; }
+.risk-medium { color: #b8860b; }
+.risk-high { color: #e65100; }
+.risk-critical { color: #c62828; }
+code { display: block; padding-left: 8px; font: 7pt "SFMono-Regular", Consolas, Menlo, monospace; color: #333; white-space: pre-wrap; }
+footer { margin-top: 4px; font-size: 7pt; color: #666; }
+</style>
+</head>
+<body>
+<header>
+<h1>{{.Title}}</h1>
+<span class="legend">{{range .Legend}} <span class="risk-{{.Risk}}">{{marker .Risk}}</span> {{.Label}}{{end}}</span>
+</header>
+<div class="sheet">
+{{- range .Sections}}
+<h2>{{.Category}}</h2>
+{{- range .Entries}}
+<div class="entry"><span class="name">{{.Name}}</span>{{$risk := .Risk}}{{with marker .Risk}} <span class="risk-{{$risk}}">{{.}}</span>{{end}} {{.Description}}
+{{- range .Examples}}
+<code>{{.}}</code>
+{{- end}}
+</div>
+{{- end}}
+{{- end}}
+</div>
+{{- with .Footer}}
+<footer>{{.}}</footer>
+{{- end}}
+</body>
+</html>
+`
+
+// writeCheatSheetLaTeX 输出 LaTeX 源文件，需用 xelatex 编译以支持中文
+func writeCheatSheetLaTeX(w io.Writer, sheet cheatSheet) error {
+	var b strings.Builder
+
+	b.WriteString("% 由 cmd4coder 生成，使用 xelatex 编译\n")
+	b.WriteString("\\documentclass[8pt]{extarticle}\n")
+	b.WriteString("\\usepackage[a4paper,landscape,margin=10mm]{geometry}\n")
+	b.WriteString("\\usepackage{multicol}\n")
+	b.WriteString("\\usepackage{xcolor}\n")
+	b.WriteString("\\usepackage{amssymb}\n")
+	b.WriteString("\\usepackage{xeCJK}\n")
+	b.WriteString("\\setlength{\\parindent}{0pt}\n")
+	b.WriteString("\\setlength{\\columnseprule}{0.4pt}\n")
+	b.WriteString("\\pagestyle{empty}\n")
+	b.WriteString("\\begin{document}\n")
+	fmt.Fprintf(&b, "{\\large\\textbf{%s}}\\hfill{\\scriptsize", escapeLaTeX(sheet.Title))
+	for _, item := range sheet.Legend {
+		fmt.Fprintf(&b, "%s %s", latexRiskMarker(item.Risk), escapeLaTeX(item.Label))
+	}
+	b.WriteString("}\\par\\hrule\\medskip\n")
+	fmt.Fprintf(&b, "\\begin{multicols*}{%d}\n\\footnotesize\n", cheatColumns)
+
+	for _, section := range sheet.Sections {
+		fmt.Fprintf(&b, "\n\\colorbox{black}{\\textcolor{white}{\\textbf{%s}}}\\par\\smallskip\n", escapeLaTeX(section.Category))
+		for _, entry := range section.Entries {
+			fmt.Fprintf(&b, "\\textbf{\\texttt{%s}}%s %s\\par\n", escapeLaTeX(entry.Name), latexRiskMarker(entry.Risk), escapeLaTeX(entry.Description))
+			for _, ex := range entry.Examples {
+				fmt.Fprintf(&b, "\\hspace*{1em}{\\scriptsize\\texttt{%s}}\\par\n", escapeLaTeX(ex))
+			}
+		}
+	}
+
+	b.WriteString("\\end{multicols*}\n")
+	if footer := sheet.Footer(); footer != "" {
+		fmt.Fprintf(&b, "{\\scriptsize %s}\n", escapeLaTeX(footer))
+	}
+	b.WriteString("\\end{document}\n")
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("failed to write LaTeX: %w", err)
+	}
+	return nil
+}
+
+// latexRiskMarker LaTeX 中的风险标记
+func latexRiskMarker(level model.RiskLevel) string {
+	switch level {
+	case model.RiskLevelMedium:
+		return " \\textcolor{orange}{$\\bullet$}"
+	case model.RiskLevelHigh:
+		return " \\textcolor{red}{$\\blacktriangle$}"
+	case model.RiskLevelCritical:
+		return " \\textcolor{red}{$\\times$}"
+	default:
+		return ""
+	}
+}
+
+// escapeLaTeX 转义 LaTeX 特殊字符
+func escapeLaTeX(s string) string {
+	return strings.NewReplacer(
+		`\`, `\textbackslash{}`,
+		`{`, `\{`,
+		`}`, `\}`,
+		`$`, `\$`,
+		`&`, `\&`,
+		`#`, `\#`,
+		`^`, `\textasciicircum{}`,
+		`_`, `\_`,
+		`%`, `\%`,
+		`~`, `\textasciitilde{}`,
+	).Replace(s)
+}
@@ -0,0 +1,336 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/cmd4coder/cmd4coder/internal/model"
+)
+
+func init() {
+	Register("zsh-completion", CompletionExporter{Shell: "zsh"})
+	Register("fish-completion", CompletionExporter{Shell: "fish"})
+}
+
+// CompletionExporter 根据命令的选项生成 shell 补全脚本
+//
+// 每个程序的补全只在该程序还没有补全时生效，不会覆盖系统或程序自带的补全。
+// zsh 在 compinit 之后 source 生成的脚本；fish 放到 ~/.config/fish/conf.d/ 下。
+type CompletionExporter struct {
+	Shell string // zsh 或 fish
+}
+
+// Extension 实现 Exporter
+func (e CompletionExporter) Extension() string {
+	return e.Shell
+}
+
+// Export 实现 Exporter
+func (e CompletionExporter) Export(w io.Writer, commands []*model.Command, opts Options) error {
+	var b strings.Builder
+	switch e.Shell {
+	case "zsh":
+		writeZshCompletion(&b, groupByTool(commands))
+	case "fish":
+		writeFishCompletion(&b, groupByTool(commands))
+	default:
+		return ErrUnknownFormat{Format: e.Shell + "-completion"}
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("failed to write completion: %w", err)
+	}
+	return nil
+}
+
+// flagSpec 从选项标志中解析出的选项名
+type flagSpec struct {
+	Short []string // 单字母短选项，不含 -
+	Long  []string // -- 长选项，不含 --
+	Old   []string // 单个 - 的长选项，如 find -name，不含 -
+	Arg   bool     // 是否带参数
+}
+
+// Names 带前缀的全部选项名
+func (f flagSpec) Names() []string {
+	var names []string
+	for _, s := range f.Short {
+		names = append(names, "-"+s)
+	}
+	for _, s := range f.Old {
+		names = append(names, "-"+s)
+	}
+	for _, s := range f.Long {
+		names = append(names, "--"+s)
+	}
+	return names
+}
+
+// parseFlag 解析选项标志，如 "-n, --namespace"、"--branch <name>"、"-Xmx<size>"
+//
+// 无法识别为选项的标志（如 dig 的 +short）返回 ok 为 false。
+func parseFlag(flag string) (spec flagSpec, ok bool) {
+	for _, part := range strings.Split(flag, ",") {
+		part = strings.TrimSpace(part)
+		name := part
+		if i := strings.IndexAny(part, " =<["); i >= 0 {
+			name = part[:i]
+			spec.Arg = true
+		}
+
+		switch {
+		case strings.HasPrefix(name, "--") && len(name) > 2:
+			spec.Long = append(spec.Long, name[2:])
+		case strings.HasPrefix(name, "-") && len(name) == 2:
+			spec.Short = append(spec.Short, name[1:])
+		case strings.HasPrefix(name, "-") && len(name) > 2:
+			spec.Old = append(spec.Old, name[1:])
+		}
+	}
+	return spec, len(spec.Short)+len(spec.Long)+len(spec.Old) > 0
+}
+
+// completionNode 程序的一级子命令路径，根节点路径为空
+type completionNode struct {
+	Path        string // 程序名之后的子命令，如 "compose up"
+	Description string
+	Options     []model.Option
+	Children    []string // 下一级子命令
+}
+
+// completionTree 把同一程序的命令整理为子命令树，按首次出现的顺序排列
+func completionTree(g toolGroup) []*completionNode {
+	var nodes []*completionNode
+	index := make(map[string]*completionNode)
+	node := func(path string) *completionNode {
+		n, ok := index[path]
+		if !ok {
+			n = &completionNode{Path: path}
+			index[path] = n
+			nodes = append(nodes, n)
+		}
+		return n
+	}
+
+	node("")
+	for _, cmd := range g.Commands {
+		words := strings.Fields(cmd.Name)[1:]
+		for i, word := range words {
+			parent, path := node(strings.Join(words[:i], " ")), strings.Join(words[:i+1], " ")
+			if _, ok := index[path]; !ok {
+				parent.Children = append(parent.Children, word)
+			}
+			node(path)
+		}
+		n := node(strings.Join(words, " "))
+		n.Description = firstLine(cmd.Description)
+		n.Options = append(n.Options, cmd.Options...)
+	}
+	return nodes
+}
+
+// writeZshCompletion 生成 zsh 补全函数
+//
+// 子命令路径由已输入的非选项参数拼成，按最长路径匹配，匹配到的命令提供选项和下一级子命令。
+func writeZshCompletion(b *strings.Builder, groups []toolGroup) {
+	b.WriteString("# 由 cmd4coder 生成，在 compinit 之后 source 本文件\n")
+	for _, g := range groups {
+		nodes := completionTree(g)
+		fn := "_cmd4coder_" + zshIdent(g.Tool)
+
+		fmt.Fprintf(b, "\n%s() {\n", fn)
+		b.WriteString("  local -a subcommands options\n  local word sub\n")
+		b.WriteString("  for word in ${words[2,CURRENT-1]}; do\n")
+		b.WriteString("    [[ $word == -* ]] || sub+=${sub:+ }$word\n")
+		b.WriteString("  done\n")
+		b.WriteString("  case $sub in\n")
+		// 先匹配较长的路径，根节点放在最后兜底
+		for i := len(nodes) - 1; i >= 0; i-- {
+			n := nodes[i]
+			if i == 0 {
+				b.WriteString("    (*)\n")
+			} else {
+				pattern := zshPattern(n.Path)
+				fmt.Fprintf(b, "    (%s|%s\\ *)\n", pattern, pattern)
+			}
+			if len(n.Children) > 0 {
+				b.WriteString("      [[ $sub == " + zshPatternOrEmpty(n.Path) + " ]] && subcommands=(")
+				for _, child := range n.Children {
+					path := strings.TrimSpace(n.Path + " " + child)
+					b.WriteString(" " + shellQuote(zshDescribe(child, nodeDescription(nodes, path))))
+				}
+				b.WriteString(" )\n")
+			}
+			if len(n.Options) > 0 {
+				b.WriteString("      options=(")
+				for _, opt := range n.Options {
+					spec, ok := parseFlag(opt.Flag)
+					if !ok {
+						continue
+					}
+					for _, name := range spec.Names() {
+						b.WriteString(" " + shellQuote(zshDescribe(name, firstLine(opt.Description))))
+					}
+				}
+				b.WriteString(" )\n")
+			}
+			b.WriteString("      ;;\n")
+		}
+		b.WriteString("  esac\n")
+		b.WriteString("  if [[ $PREFIX == -* ]]; then\n")
+		b.WriteString("    (( $#options )) && _describe -t options 'option' options\n")
+		b.WriteString("  elif (( $#subcommands )); then\n")
+		b.WriteString("    _describe -t commands 'command' subcommands\n")
+		b.WriteString("  else\n")
+		b.WriteString("    _files\n")
+		b.WriteString("  fi\n")
+		b.WriteString("}\n")
+		fmt.Fprintf(b, "(( $+_comps[%s] )) || compdef %s %s\n", g.Tool, fn, shellQuote(g.Tool))
+	}
+}
+
+// writeFishCompletion 生成 fish 补全
+func writeFishCompletion(b *strings.Builder, groups []toolGroup) {
+	b.WriteString(`# 由 cmd4coder 生成，放到 ~/.config/fish/conf.d/ 下
+
+# __cmd4coder_has_completion 程序是否已有补全文件
+function __cmd4coder_has_completion
+    for dir in $fish_complete_path
+        test -e $dir/$argv[1].fish; and return 0
+    end
+    return 1
+end
+
+# __cmd4coder_path 已输入的子命令路径（忽略选项）是否等于参数；--prefix 时判断是否以参数开头
+function __cmd4coder_path
+    set -l prefix 0
+    if test "$argv[1]" = --prefix
+        set prefix 1
+        set -e argv[1]
+    end
+    set -l path
+    for word in (commandline -opc)[2..-1]
+        string match -q -- '-*' $word; or set -a path $word
+    end
+    if test $prefix = 1
+        test (count $argv) -eq 0; and return 0
+        test "$path[1..(count $argv)]" = "$argv"
+    else
+        test "$path" = "$argv"
+    end
+end
+`)
+	for _, g := range groups {
+		fmt.Fprintf(b, "\nif not __cmd4coder_has_completion %s\n", fishQuote(g.Tool))
+		nodes := completionTree(g)
+		for _, n := range nodes {
+			for _, child := range n.Children {
+				path := strings.TrimSpace(n.Path + " " + child)
+				fmt.Fprintf(b, "    complete -c %s -f -n %s -a %s", fishQuote(g.Tool), fishQuote(fishCondition(false, n.Path)), fishQuote(child))
+				if desc := nodeDescription(nodes, path); desc != "" {
+					fmt.Fprintf(b, " -d %s", fishQuote(desc))
+				}
+				b.WriteString("\n")
+			}
+			for _, opt := range n.Options {
+				spec, ok := parseFlag(opt.Flag)
+				if !ok {
+					continue
+				}
+				fmt.Fprintf(b, "    complete -c %s -n %s", fishQuote(g.Tool), fishQuote(fishCondition(true, n.Path)))
+				for _, s := range spec.Short {
+					b.WriteString(" -s " + fishQuote(s))
+				}
+				for _, s := range spec.Old {
+					b.WriteString(" -o " + fishQuote(s))
+				}
+				for _, s := range spec.Long {
+					b.WriteString(" -l " + fishQuote(s))
+				}
+				if spec.Arg {
+					b.WriteString(" -r")
+				}
+				if desc := firstLine(opt.Description); desc != "" {
+					fmt.Fprintf(b, " -d %s", fishQuote(desc))
+				}
+				b.WriteString("\n")
+			}
+		}
+		b.WriteString("end\n")
+	}
+}
+
+// nodeDescription 查找子命令路径对应的描述
+func nodeDescription(nodes []*completionNode, path string) string {
+	for _, n := range nodes {
+		if n.Path == path {
+			return n.Description
+		}
+	}
+	return ""
+}
+
+// fishCondition 生成 __cmd4coder_path 条件
+func fishCondition(prefix bool, path string) string {
+	cond := "__cmd4coder_path"
+	if prefix {
+		cond += " --prefix"
+	}
+	for _, word := range strings.Fields(path) {
+		cond += " " + fishQuote(word)
+	}
+	return cond
+}
+
+// zshDescribe 生成 _describe 的 "名称:描述" 条目，名称中的冒号需要转义
+func zshDescribe(name, desc string) string {
+	name = strings.ReplaceAll(name, ":", `\:`)
+	if desc == "" {
+		return name
+	}
+	return name + ":" + desc
+}
+
+// zshPattern 把子命令路径转为 case 模式，转义空格和通配符
+func zshPattern(path string) string {
+	var b strings.Builder
+	for _, r := range path {
+		if strings.ContainsRune(` *?[]()|<>#~^\'"`, r) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// zshPatternOrEmpty 根路径对应空字符串
+func zshPatternOrEmpty(path string) string {
+	if path == "" {
+		return `''`
+	}
+	return zshPattern(path)
+}
+
+// zshIdent 把程序名转为可用作函数名的标识符
+func zshIdent(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		if r == '_' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' {
+			b.WriteRune(r)
+		} else {
+			b.WriteByte('_')
+		}
+	}
+	return b.String()
+}
+
+// shellQuote 用单引号包裹，供 zsh 使用
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// fishQuote 用单引号包裹，fish 的单引号内需要转义反斜杠和单引号
+func fishQuote(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, "'", `\'`).Replace(s) + "'"
+}
@@ -0,0 +1,12 @@
+package export
+
+import "fmt"
+
+// ErrUnknownFormat 未注册的导出格式
+type ErrUnknownFormat struct {
+	Format string
+}
+
+func (e ErrUnknownFormat) Error() string {
+	return fmt.Sprintf("unknown export format: %s", e.Format)
+}
@@ -1,8 +1,14 @@
 package export
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"testing"
 
 	"github.com/cmd4coder/cmd4coder/internal/model"
@@ -66,6 +72,171 @@ func TestExportToMarkdown(t *testing.T) {
 	}
 }
 
+func TestWriteCommandMarkdown(t *testing.T) {
+	cmd := &model.Command{
+		Name:        "test-cmd",
+		Description: "Test command",
+		Platforms:   []string{"linux", "macos"},
+		Options:     []model.Option{{Flag: "-h", Description: "Show help"}},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCommandMarkdown(&buf, cmd); err != nil {
+		t.Fatalf("WriteCommandMarkdown() error = %v", err)
+	}
+
+	for _, expected := range []string{"### test-cmd", "**平台**: linux, macos", "- `-h`: Show help"} {
+		if !contains(buf.String(), expected) {
+			t.Errorf("Output does not contain expected string: %s", expected)
+		}
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	for _, format := range []string{"json", "json-compact", "markdown"} {
+		if _, err := Get(format); err != nil {
+			t.Errorf("Get(%q) error = %v", format, err)
+		}
+	}
+	if _, err := Get("xml"); !errors.As(err, &ErrUnknownFormat{}) {
+		t.Errorf("Expected ErrUnknownFormat, got %v", err)
+	}
+	if formats := Formats(); len(formats) < 3 || !sort.StringsAreSorted(formats) {
+		t.Errorf("Formats() = %v", formats)
+	}
+}
+
+func TestJSONExporter(t *testing.T) {
+	commands := []*model.Command{{
+		Name:     "test-cmd",
+		Category: "test",
+		Risks:    []model.Risk{{Level: model.RiskLevelHigh, Description: "Dangerous"}},
+	}}
+
+	var buf bytes.Buffer
+	opts := Options{Title: "On-call"}
+	if err := (JSONExporter{}).Export(&buf, commands, opts); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	for _, expected := range []string{`"title": "On-call"`, `"total": 1`, `"name": "test-cmd"`} {
+		if !contains(buf.String(), expected) {
+			t.Errorf("JSON output does not contain %s:\n%s", expected, buf.String())
+		}
+	}
+	if contains(buf.String(), "Dangerous") {
+		t.Error("Risks should be omitted when IncludeRisks is false")
+	}
+	if len(commands[0].Risks) != 1 {
+		t.Error("Export should not modify the original commands")
+	}
+}
+
+func TestMarkdownExporter(t *testing.T) {
+	commands := []*model.Command{
+		{Name: "b-cmd", Category: "beta", Description: "B"},
+		{Name: "a-cmd", Category: "alpha", Description: "A", Risks: []model.Risk{{Level: model.RiskLevelLow, Description: "Safe"}}},
+	}
+
+	var buf bytes.Buffer
+	opts := DefaultOptions()
+	opts.Locale = "en"
+	if err := (MarkdownExporter{}).Export(&buf, commands, opts); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	out := buf.String()
+	for _, expected := range []string{"# Command Reference", "Total commands: 2", "## Contents", "- [beta](#cat-beta)\n  - [b-cmd](#cmd-b-cmd)", `## <a id="cat-beta"></a>beta`, `### <a id="cmd-b-cmd"></a>b-cmd`, "**Risks**", "Safe"} {
+		if !contains(out, expected) {
+			t.Errorf("Markdown output does not contain %s", expected)
+		}
+	}
+	if strings.Index(out, "</a>beta") > strings.Index(out, "</a>alpha") {
+		t.Error("Categories should keep their first-appearance order")
+	}
+
+	buf.Reset()
+	opts = Options{Title: "Flat", GroupBy: GroupNone}
+	if err := (MarkdownExporter{}).Export(&buf, commands, opts); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	if !contains(buf.String(), "# Flat") || !contains(buf.String(), "- [a-cmd](#cmd-a-cmd)") || !contains(buf.String(), `## <a id="cmd-a-cmd"></a>a-cmd`) || contains(buf.String(), "Safe") {
+		t.Errorf("Unexpected flat output:\n%s", buf.String())
+	}
+}
+
+func TestMarkdownExporter_Escaping(t *testing.T) {
+	commands := []*model.Command{
+		{
+			Name:            "git log",
+			Category:        "Git",
+			Description:     "查看 `HEAD` 的 *提交* 历史\n第二行",
+			Options:         []model.Option{{Flag: "--format=`%h`", Description: "格式 [短]"}},
+			Examples:        []model.Example{{Command: "git log --oneline", Description: "简洁", Output: "abc123 first\ndef456 ```second```"}},
+			RelatedCommands: []string{"git show", "git blame"},
+			References:      []string{"https://git-scm.com/docs/git-log"},
+		},
+		{Name: "git show", Category: "Git", Description: "查看提交"},
+		{Name: "ls", Category: "Shell", Description: "列出文件", RelatedCommands: []string{"git log"}},
+	}
+
+	var buf bytes.Buffer
+	if err := (MarkdownExporter{}).Export(&buf, commands, DefaultOptions()); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	out := buf.String()
+	for _, expected := range []string{
+		"**描述**: 查看 \\`HEAD\\` 的 \\*提交\\* 历史 第二行\n",
+		"- `` --format=`%h` ``: 格式 \\[短\\]\n",
+		"   输出:\n   ````\n   abc123 first\n   def456 ```second```\n   ````\n",
+		"**相关命令**: [git show](#cmd-git-show), `git blame`",
+		"**相关命令**: [git log](#cmd-git-log)",
+		"- <https://git-scm.com/docs/git-log>",
+	} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("Markdown output does not contain %q:\n%s", expected, out)
+		}
+	}
+}
+
+func TestMarkdownExporter_ExportDir(t *testing.T) {
+	commands := []*model.Command{
+		{Name: "git log", Category: "Git", Description: "查看提交历史", RelatedCommands: []string{"git show"}},
+		{Name: "git show", Category: "Git", Description: "查看提交"},
+		{Name: "ls", Category: "Shell", Description: "列出文件", RelatedCommands: []string{"git log"}},
+	}
+
+	dir := t.TempDir()
+	if err := (MarkdownExporter{}).ExportDir(dir, commands, DefaultOptions()); err != nil {
+		t.Fatalf("ExportDir() error = %v", err)
+	}
+
+	index, err := os.ReadFile(filepath.Join(dir, "README.md"))
+	if err != nil {
+		t.Fatalf("Failed to read index: %v", err)
+	}
+	if !contains(string(index), "- [Git](git.md) (2)\n- [Shell](shell.md) (1)\n") {
+		t.Errorf("Unexpected index:\n%s", index)
+	}
+
+	shell, err := os.ReadFile(filepath.Join(dir, "shell.md"))
+	if err != nil {
+		t.Fatalf("Failed to read category file: %v", err)
+	}
+	// 跨文件的相关命令链接到对应文件
+	for _, expected := range []string{"# Shell", "[命令行工具大全](README.md)", "- [ls](#cmd-ls)", "[git log](git.md#cmd-git-log)"} {
+		if !contains(string(shell), expected) {
+			t.Errorf("Category file does not contain %s:\n%s", expected, shell)
+		}
+	}
+
+	git, err := os.ReadFile(filepath.Join(dir, "git.md"))
+	if err != nil {
+		t.Fatalf("Failed to read category file: %v", err)
+	}
+	if !contains(string(git), "[git show](#cmd-git-show)") {
+		t.Errorf("Links within the same file should use anchors only:\n%s", git)
+	}
+}
+
 func TestExportToJSON(t *testing.T) {
 	commands := []*model.Command{
 		{
@@ -141,3 +312,395 @@ func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(substr) == 0 ||
 		(len(s) > 0 && (s[0:len(substr)] == substr || contains(s[1:], substr))))
 }
+
+func TestHTMLExporter_ExportDir(t *testing.T) {
+	commands := []*model.Command{
+		{
+			Name:            "git log",
+			Category:        "版本控制/Git命令",
+			Description:     "查看提交 <历史>",
+			Platforms:       []string{"linux"},
+			RelatedCommands: []string{"git show", "git blame"},
+			Risks:           []model.Risk{{Level: model.RiskLevelLow, Description: "只读"}},
+		},
+		{Name: "git show", Category: "版本控制/Git命令", Description: "查看提交"},
+		{Name: "rm", Category: "操作系统/通用Linux命令", Description: "删除文件", Risks: []model.Risk{{Level: model.RiskLevelCritical, Description: "不可恢复"}}},
+	}
+
+	dir := t.TempDir()
+	if err := (HTMLExporter{}).ExportDir(dir, commands, DefaultOptions()); err != nil {
+		t.Fatalf("ExportDir() error = %v", err)
+	}
+
+	for _, name := range []string{
+		"index.html", "search-index.json", "search-index.js", "assets/site.css", "assets/search.js",
+		"categories/版本控制-git命令.html", "commands/git-log.html", "commands/rm.html",
+	} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("Expected %s to be generated: %v", name, err)
+		}
+	}
+
+	page, err := os.ReadFile(filepath.Join(dir, "commands", "git-log.html"))
+	if err != nil {
+		t.Fatalf("Failed to read command page: %v", err)
+	}
+	for _, expected := range []string{
+		`<a href="../commands/git-show.html">git show</a>`,
+		"<li>git blame</li>",
+		"查看提交 &lt;历史&gt;",
+		`<span class="risk risk-low">低风险</span>`,
+		`版本控制/Git命令</a>`,
+	} {
+		if !contains(string(page), expected) {
+			t.Errorf("Command page does not contain %s", expected)
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "search-index.json"))
+	if err != nil {
+		t.Fatalf("Failed to read search index: %v", err)
+	}
+	var entries []searchEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		t.Fatalf("Invalid search index: %v", err)
+	}
+	if len(entries) != 3 || entries[2].URL != "commands/rm.html" || entries[2].Risk != model.RiskLevelCritical {
+		t.Errorf("Unexpected search index: %+v", entries)
+	}
+}
+
+func TestHTMLExporter_Export(t *testing.T) {
+	commands := []*model.Command{
+		{Name: "git log", Category: "版本控制/Git命令", Description: "查看提交历史", RelatedCommands: []string{"git show"}},
+		{Name: "git show", Category: "版本控制/Git命令", Description: "查看提交"},
+		{Name: "rm", Category: "操作系统/通用Linux命令", Description: "删除文件", Risks: []model.Risk{{Level: model.RiskLevelCritical, Description: "不可恢复"}}},
+	}
+
+	var buf bytes.Buffer
+	if err := (HTMLExporter{}).Export(&buf, commands, DefaultOptions()); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	out := buf.String()
+	for _, expected := range []string{"window.CMD4CODER_INDEX", `"url":"#cmd-rm"`, `<section id="cmd-git-log">`, `<a href="#cmd-git-show">git show</a>`} {
+		if !contains(out, expected) {
+			t.Errorf("Single page does not contain %s", expected)
+		}
+	}
+
+	// 不包含风险时不显示风险标记，也不写入搜索索引
+	opts := DefaultOptions()
+	opts.IncludeRisks = false
+	buf.Reset()
+	if err := (HTMLExporter{}).Export(&buf, commands, opts); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	if out := buf.String(); contains(out, `class="risk `) || contains(out, `"risk":`) {
+		t.Errorf("Single page shows risks with IncludeRisks disabled")
+	}
+}
+
+func TestSlugSet(t *testing.T) {
+	set := newSlugSet()
+	for _, tc := range []struct{ name, want string }{
+		{"kubectl get", "kubectl-get"},
+		{"kubectl-get", "kubectl-get-2"},
+		{"操作系统/Ubuntu系统命令", "操作系统-ubuntu系统命令"},
+		{"Cloud / AWS CLI/", "cloud-aws-cli"},
+		{"--", "item"},
+	} {
+		if got := set.add(tc.name); got != tc.want {
+			t.Errorf("add(%q) = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestPlanCheatSheet(t *testing.T) {
+	newCommands := func(n int) []*model.Command {
+		commands := make([]*model.Command, 0, n)
+		for i := 0; i < n; i++ {
+			commands = append(commands, &model.Command{
+				Name:        fmt.Sprintf("cmd-%03d", i),
+				Category:    fmt.Sprintf("分类%d", i%3),
+				Description: "一行描述\n第二行不应出现",
+				Examples: []model.Example{
+					{Command: fmt.Sprintf("cmd-%03d --first", i)},
+					{Command: fmt.Sprintf("cmd-%03d --second", i)},
+					{Command: fmt.Sprintf("cmd-%03d --third", i)},
+				},
+			})
+		}
+		return commands
+	}
+
+	// 不限页数时保留全部命令和最多两个示例
+	sheet := planCheatSheet(newCommands(30), DefaultOptions())
+	if sheet.Omitted != 0 || len(sheet.Sections) != 3 || sheet.Pages != 1 {
+		t.Fatalf("Unexpected unlimited sheet: omitted=%d sections=%d pages=%d", sheet.Omitted, len(sheet.Sections), sheet.Pages)
+	}
+	entry := sheet.Sections[0].Entries[0]
+	if entry.Description != "一行描述" || len(entry.Examples) != cheatMaxExamples {
+		t.Errorf("Unexpected entry: %+v", entry)
+	}
+
+	// 超出预算时先减少示例
+	opts := DefaultOptions()
+	opts.PageBudget = 1
+	sheet = planCheatSheet(newCommands(150), opts)
+	if sheet.Omitted != 0 || sheet.Pages != 1 {
+		t.Fatalf("Expected examples to be dropped to fit, got omitted=%d pages=%d", sheet.Omitted, sheet.Pages)
+	}
+	if n := len(sheet.Sections[0].Entries[0].Examples); n >= cheatMaxExamples {
+		t.Errorf("Expected fewer examples, got %d", n)
+	}
+
+	// 去掉示例仍放不下时截断命令
+	sheet = planCheatSheet(newCommands(400), opts)
+	if sheet.Omitted == 0 || sheet.Pages != 1 {
+		t.Fatalf("Expected commands to be omitted, got omitted=%d pages=%d", sheet.Omitted, sheet.Pages)
+	}
+	if !strings.Contains(sheet.Footer(), fmt.Sprint(sheet.Omitted)) {
+		t.Errorf("Footer should mention omitted count: %q", sheet.Footer())
+	}
+
+	// 截断发生在末尾：列出的命令恰好是输入的前缀
+	commands := newCommands(400)
+	listed := make(map[string]bool)
+	for _, section := range sheet.Sections {
+		for _, entry := range section.Entries {
+			listed[entry.Name] = true
+		}
+	}
+	kept := len(commands) - sheet.Omitted
+	for i, cmd := range commands {
+		if listed[cmd.Name] != (i < kept) {
+			t.Fatalf("Expected the first %d commands to be listed, %s listed=%v", kept, cmd.Name, listed[cmd.Name])
+		}
+	}
+
+	n, omitted, pages := (CheatSheetExporter{}).Layout(commands, opts)
+	if n != kept || omitted != sheet.Omitted || pages != sheet.Pages {
+		t.Errorf("Layout() = %d, %d, %d, want %d, %d, %d", n, omitted, pages, kept, sheet.Omitted, sheet.Pages)
+	}
+}
+
+func TestCheatSheetExporter(t *testing.T) {
+	commands := []*model.Command{
+		{Name: "git log", Category: "版本控制/Git命令", Description: "查看提交 <历史>"},
+		{Name: "rm", Category: "操作系统/通用Linux命令", Description: "删除文件", Risks: []model.Risk{{Level: model.RiskLevelCritical, Description: "不可恢复"}}},
+	}
+
+	var buf bytes.Buffer
+	if err := (CheatSheetExporter{}).Export(&buf, commands, DefaultOptions()); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	out := buf.String()
+	for _, expected := range []string{"column-count: 3", "@page { size: A4 landscape", `<span class="risk-critical">✖</span>`, "查看提交 &lt;历史&gt;"} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("HTML cheat sheet does not contain %s", expected)
+		}
+	}
+
+	buf.Reset()
+	commands = []*model.Command{{Name: "grep", Category: "文本", Description: "匹配 $HOME & 100%", Examples: []model.Example{{Command: `grep -E '^a_b{2}' ~/x`}}}}
+	if err := (CheatSheetExporter{LaTeX: true}).Export(&buf, commands, DefaultOptions()); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	out = buf.String()
+	for _, expected := range []string{`\begin{multicols*}{3}`, `匹配 \$HOME \& 100\%`, `grep -E '\textasciicircum{}a\_b\{2\}' \textasciitilde{}/x`} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("LaTeX cheat sheet does not contain %s:\n%s", expected, out)
+		}
+	}
+}
+
+func TestParseFlag(t *testing.T) {
+	for _, tc := range []struct {
+		flag  string
+		names []string
+		arg   bool
+	}{
+		{"-n, --namespace", []string{"-n", "--namespace"}, false},
+		{"--branch <name>", []string{"--branch"}, true},
+		{"--author=<name>", []string{"--author"}, true},
+		{"-Xmx<size>", []string{"-Xmx"}, true},
+		{"-name pattern", []string{"-name"}, true},
+		{"+short", nil, false},
+	} {
+		spec, ok := parseFlag(tc.flag)
+		if ok != (tc.names != nil) || strings.Join(spec.Names(), " ") != strings.Join(tc.names, " ") || spec.Arg != tc.arg {
+			t.Errorf("parseFlag(%q) = %+v, %v", tc.flag, spec, ok)
+		}
+	}
+}
+
+func TestManExporter(t *testing.T) {
+	commands := []*model.Command{
+		{
+			Name:        "kubectl get",
+			Description: "获取资源",
+			Usage:       []string{".hidden -n default"},
+			Options:     []model.Option{{Flag: "-n, --namespace", Description: "命名空间"}},
+		},
+		{Name: "kubectl rollout status", Description: "查看发布状态"},
+		{Name: "find", Description: "查找文件"},
+	}
+
+	dir := t.TempDir()
+	if err := (ManExporter{}).ExportDir(dir, commands, DefaultOptions()); err != nil {
+		t.Fatalf("ExportDir() error = %v", err)
+	}
+
+	page, err := os.ReadFile(filepath.Join(dir, "man7", "cmd4coder-kubectl.7"))
+	if err != nil {
+		t.Fatalf("Failed to read man page: %v", err)
+	}
+	for _, expected := range []string{
+		`.TH "CMD4CODER\-KUBECTL" 7`,
+		"cmd4coder\\-kubectl \\- kubectl 命令速查",
+		`.SH "kubectl rollout status"`,
+		".B \"\\-n, \\-\\-namespace\"\n命名空间",
+		"\\&.hidden \\-n default",
+	} {
+		if !strings.Contains(string(page), expected) {
+			t.Errorf("Man page does not contain %q:\n%s", expected, page)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "man7", "cmd4coder-find.7")); err != nil {
+		t.Errorf("Expected a page per tool: %v", err)
+	}
+
+	// 多个程序写入同一页时按程序分段
+	var buf bytes.Buffer
+	if err := (ManExporter{}).Export(&buf, commands, DefaultOptions()); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), ".SH \"find\"\n.SS \"find\"") {
+		t.Errorf("Combined page should group by tool:\n%s", buf.String())
+	}
+}
+
+func TestCompletionExporter(t *testing.T) {
+	commands := []*model.Command{
+		{
+			Name:        "kubectl get",
+			Description: "获取资源",
+			Usage:       []string{".hidden -n default"},
+			Options: []model.Option{
+				{Flag: "-n, --namespace", Description: "命名空间"},
+				{Flag: "-o wide", Description: "显示更多列"},
+			},
+			Examples: []model.Example{{Command: `kubectl get pods -o 'jsonpath={.items[*]}'`, Description: "列出 Pod"}},
+		},
+		{Name: "kubectl rollout status", Description: "查看发布状态", Options: []model.Option{{Flag: "-w, --watch", Description: "持续观察"}}},
+		{Name: "find", Description: "查找文件", Options: []model.Option{{Flag: "-name pattern", Description: "按名称"}, {Flag: "+x", Description: "不是选项"}}},
+	}
+
+	var buf bytes.Buffer
+	if err := (CompletionExporter{Shell: "zsh"}).Export(&buf, commands, DefaultOptions()); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	out := buf.String()
+	for _, expected := range []string{
+		"(rollout\\ status|rollout\\ status\\ *)",
+		"[[ $sub == '' ]] && subcommands=( 'get:获取资源' 'rollout' )",
+		"[[ $sub == rollout ]] && subcommands=( 'status:查看发布状态' )",
+		"options=( '-n:命名空间' '--namespace:命名空间' '-o:显示更多列' )",
+		"options=( '-name:按名称' )",
+		"(( $+_comps[kubectl] )) || compdef _cmd4coder_kubectl 'kubectl'",
+	} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("zsh completion does not contain %q:\n%s", expected, out)
+		}
+	}
+	if strings.Index(out, "(rollout\\ status|") > strings.Index(out, "(rollout|") {
+		t.Error("Longer subcommand paths should be matched first")
+	}
+
+	buf.Reset()
+	if err := (CompletionExporter{Shell: "fish"}).Export(&buf, commands, DefaultOptions()); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	out = buf.String()
+	for _, expected := range []string{
+		"if not __cmd4coder_has_completion 'kubectl'",
+		`complete -c 'kubectl' -f -n '__cmd4coder_path \'rollout\'' -a 'status' -d '查看发布状态'`,
+		`complete -c 'kubectl' -n '__cmd4coder_path --prefix \'get\'' -s 'n' -l 'namespace' -d '命名空间'`,
+		`complete -c 'kubectl' -n '__cmd4coder_path --prefix \'get\'' -s 'o' -r -d '显示更多列'`,
+		`complete -c 'find' -n '__cmd4coder_path --prefix' -o 'name' -r -d '按名称'`,
+	} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("fish completion does not contain %q:\n%s", expected, out)
+		}
+	}
+}
+
+func TestAnkiExporter(t *testing.T) {
+	commands := []*model.Command{
+		{
+			Name:        "rm",
+			Category:    "操作系统/通用 Linux 命令",
+			Description: "删除文件",
+			Examples:    []model.Example{{Command: "rm -rf <dir>", Description: "递归删除目录"}, {Command: "rm\tfile"}},
+			Notes:       []string{"不可恢复"},
+			Risks:       []model.Risk{{Level: model.RiskLevelCritical, Description: "误删"}},
+		},
+		{Name: "pwd", Category: "操作系统", Description: "显示当前目录", Usage: []string{"pwd -P"}},
+	}
+
+	var buf bytes.Buffer
+	if err := (AnkiExporter{}).Export(&buf, commands, DefaultOptions()); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if lines[0] != "#separator:tab" || !contains(buf.String(), "#deck:命令行工具大全\n") {
+		t.Errorf("Unexpected header:\n%s", buf.String())
+	}
+
+	var cards [][]string
+	for _, line := range lines {
+		if !strings.HasPrefix(line, "#") {
+			cards = append(cards, strings.Split(line, "\t"))
+		}
+	}
+	if len(cards) != 3 {
+		t.Fatalf("Expected 3 cards, got %d:\n%s", len(cards), buf.String())
+	}
+	for _, card := range cards {
+		if len(card) != 4 {
+			t.Fatalf("Expected 4 columns, got %q", card)
+		}
+	}
+
+	if cards[0][0] != "cmd4coder:rm#1" || cards[0][1] != "递归删除目录" {
+		t.Errorf("Unexpected first card: %q", cards[0])
+	}
+	for _, expected := range []string{"<code>rm -rf &lt;dir&gt;</code>", "<li>不可恢复</li>", "<li>[严重风险] 误删</li>"} {
+		if !contains(cards[0][2], expected) {
+			t.Errorf("Card back does not contain %s: %s", expected, cards[0][2])
+		}
+	}
+	if cards[0][3] != "cmd4coder 操作系统::通用_Linux_命令 risk::critical" {
+		t.Errorf("Unexpected tags: %q", cards[0][3])
+	}
+	if cards[1][1] != "删除文件" || !contains(cards[1][2], "<code>rm file</code>") {
+		t.Errorf("Example without description should use command description: %q", cards[1])
+	}
+	if cards[2][0] != "cmd4coder:pwd" || !contains(cards[2][2], "<code>pwd -P</code>") {
+		t.Errorf("Command without examples should use usage: %q", cards[2])
+	}
+	if cards[2][3] != "cmd4coder 操作系统" {
+		t.Errorf("Command without risks should not get a risk tag: %q", cards[2][3])
+	}
+
+	opts := DefaultOptions()
+	opts.IncludeRisks = false
+	buf.Reset()
+	if err := (AnkiExporter{}).Export(&buf, commands, opts); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	if contains(buf.String(), "risk::") || contains(buf.String(), "误删") {
+		t.Errorf("Risks should be omitted without IncludeRisks:\n%s", buf.String())
+	}
+}
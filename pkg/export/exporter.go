@@ -0,0 +1,150 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/cmd4coder/cmd4coder/internal/model"
+)
+
+// Grouping 命令的分组方式
+type Grouping string
+
+const (
+	GroupByCategory Grouping = "category" // 按分类分组
+	GroupNone       Grouping = "none"     // 不分组，平铺输出
+)
+
+// Options 导出选项，各导出格式按需使用
+type Options struct {
+	Title        string   // 文档标题，为空时使用默认标题
+	GroupBy      Grouping // 分组方式
+	IncludeRisks bool     // 是否包含风险说明
+	Locale       string   // 界面文字语言: zh/en
+	PageBudget   int      // 页数上限，仅速查表使用，0 表示不限
+}
+
+// DefaultOptions 返回默认导出选项
+func DefaultOptions() Options {
+	return Options{
+		GroupBy:      GroupByCategory,
+		IncludeRisks: true,
+		Locale:       "zh",
+	}
+}
+
+// Exporter 把命令导出为某种格式
+type Exporter interface {
+	// Extension 导出文件的默认扩展名，不含点
+	Extension() string
+	// Export 把命令写到 w
+	Export(w io.Writer, commands []*model.Command, opts Options) error
+}
+
+// DirExporter 生成多个文件的导出格式（如静态站点），导出到目录
+type DirExporter interface {
+	ExportDir(dir string, commands []*model.Command, opts Options) error
+}
+
+// PagedExporter 按页数排版、可能因 Options.PageBudget 省略命令的导出格式（如速查表）
+type PagedExporter interface {
+	// Layout 返回按 opts 导出时列出的命令数、省略的命令数和预计页数
+	Layout(commands []*model.Command, opts Options) (listed, omitted, pages int)
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]Exporter)
+)
+
+// Register 注册导出格式，重复注册同名格式会 panic
+func Register(format string, e Exporter) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if _, exists := registry[format]; exists {
+		panic(fmt.Sprintf("export: format %q registered twice", format))
+	}
+	registry[format] = e
+}
+
+// Get 获取已注册的导出格式
+func Get(format string) (Exporter, error) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	e, ok := registry[format]
+	if !ok {
+		return nil, ErrUnknownFormat{Format: format}
+	}
+	return e, nil
+}
+
+// Formats 返回所有已注册的导出格式，按名称排序
+func Formats() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	formats := make([]string, 0, len(registry))
+	for format := range registry {
+		formats = append(formats, format)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+// ExportToFile 以指定导出格式把命令写入文件
+func ExportToFile(e Exporter, commands []*model.Command, filename string, opts Options) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	if err := e.Export(f, commands, opts); err != nil {
+		return err
+	}
+
+	return f.Close()
+}
+
+// withoutRisks 返回去掉风险说明的命令副本，不修改原命令
+func withoutRisks(commands []*model.Command) []*model.Command {
+	copied := make([]*model.Command, len(commands))
+	for i, cmd := range commands {
+		c := *cmd
+		c.Risks = nil
+		copied[i] = &c
+	}
+	return copied
+}
+
+// toolGroup 同一个程序的命令，如 kubectl get、kubectl apply
+type toolGroup struct {
+	Tool     string
+	Commands []*model.Command
+}
+
+// groupByTool 按命令名称的第一个词分组，保持首次出现的顺序
+func groupByTool(commands []*model.Command) []toolGroup {
+	var groups []toolGroup
+	index := make(map[string]int)
+	for _, cmd := range commands {
+		fields := strings.Fields(cmd.Name)
+		if len(fields) == 0 {
+			continue
+		}
+		i, ok := index[fields[0]]
+		if !ok {
+			i = len(groups)
+			index[fields[0]] = i
+			groups = append(groups, toolGroup{Tool: fields[0]})
+		}
+		groups[i].Commands = append(groups[i].Commands, cmd)
+	}
+	return groups
+}
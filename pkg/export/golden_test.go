@@ -0,0 +1,97 @@
+package export
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cmd4coder/cmd4coder/internal/model"
+)
+
+// update 重新生成 golden 文件：go test ./pkg/export -run TestGolden -update
+var update = flag.Bool("update", false, "update golden files")
+
+// newGoldenCommands 覆盖各导出格式主要字段的命令，已按分类顺序和名称排列
+func newGoldenCommands() []*model.Command {
+	return []*model.Command{
+		{
+			Name:            "ls",
+			Category:        "操作系统/通用Linux命令",
+			Description:     "列出目录内容",
+			Platforms:       []string{"linux", "darwin"},
+			Usage:           []string{"ls [OPTION]... [FILE]..."},
+			Options:         []model.Option{{Flag: "-l", Description: "长格式显示"}, {Flag: "-a, --all", Description: "显示隐藏文件"}},
+			Examples:        []model.Example{{Command: "ls -la", Description: "列出所有文件的详细信息"}},
+			RelatedCommands: []string{"rm"},
+		},
+		{
+			Name:        "rm",
+			Category:    "操作系统/通用Linux命令",
+			Description: "删除文件或目录",
+			Platforms:   []string{"linux", "darwin"},
+			Usage:       []string{"rm [OPTION]... FILE..."},
+			Options:     []model.Option{{Flag: "-r, -R", Description: "递归删除"}, {Flag: "-f, --force", Description: "强制删除"}},
+			Examples:    []model.Example{{Command: "rm -rf ./build", Description: "删除构建目录"}},
+			Notes:       []string{"删除后无法恢复"},
+			Risks:       []model.Risk{{Level: model.RiskLevelCritical, Description: "误删数据"}},
+		},
+		{
+			Name:        "git stash pop",
+			Category:    "版本控制/Git命令",
+			Description: "恢复最近一次贮藏",
+			Platforms:   []string{"linux", "darwin", "windows"},
+			Usage:       []string{"git stash pop [--index] [<stash>]"},
+			Options:     []model.Option{{Flag: "--index", Description: "同时恢复暂存区"}},
+			Examples:    []model.Example{{Command: "git stash pop stash@{1}", Description: "恢复指定贮藏"}},
+			Risks:       []model.Risk{{Level: model.RiskLevelMedium, Description: "可能产生冲突"}},
+		},
+	}
+}
+
+// TestGolden 各导出格式的输出与 testdata/golden 下的文件逐字节一致，保证输出稳定、便于在 git 中比较
+func TestGolden(t *testing.T) {
+	for _, format := range Formats() {
+		format := format
+		t.Run(format, func(t *testing.T) {
+			exporter, err := Get(format)
+			if err != nil {
+				t.Fatalf("Get(%q) error = %v", format, err)
+			}
+
+			var buf bytes.Buffer
+			if err := exporter.Export(&buf, newGoldenCommands(), DefaultOptions()); err != nil {
+				t.Fatalf("Export() error = %v", err)
+			}
+
+			// 连续两次导出结果一致
+			var again bytes.Buffer
+			if err := exporter.Export(&again, newGoldenCommands(), DefaultOptions()); err != nil {
+				t.Fatalf("Export() error = %v", err)
+			}
+			if !bytes.Equal(buf.Bytes(), again.Bytes()) {
+				t.Fatal("Export() output is not deterministic")
+			}
+
+			golden := filepath.Join("testdata", "golden", format+"."+exporter.Extension())
+			if *update {
+				if err := os.MkdirAll(filepath.Dir(golden), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(golden, buf.Bytes(), 0644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("Failed to read golden file (run with -update to create it): %v", err)
+			}
+			if !bytes.Equal(buf.Bytes(), want) {
+				t.Errorf("Output differs from %s (run with -update to accept):\n%s", golden, buf.String())
+			}
+		})
+	}
+}
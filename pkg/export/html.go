@@ -0,0 +1,437 @@
+package export
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/cmd4coder/cmd4coder/internal/model"
+)
+
+//go:embed assets/site.css
+var siteCSS string
+
+//go:embed assets/search.js
+var searchJS string
+
+func init() {
+	Register("html", HTMLExporter{})
+}
+
+// HTMLExporter 导出为HTML
+//
+// Export 生成包含全部命令和离线搜索的单个页面；ExportDir 生成静态站点：
+// 首页、每个分类和每个命令各一个页面，以及供前端离线搜索使用的 JSON 索引。
+type HTMLExporter struct{}
+
+// Extension 实现 Exporter
+func (e HTMLExporter) Extension() string {
+	return "html"
+}
+
+// Export 实现 Exporter，输出单个自包含的页面
+func (e HTMLExporter) Export(w io.Writer, commands []*model.Command, opts Options) error {
+	s := newSite(commands, opts)
+
+	// 单页模式下搜索结果跳转到页内锚点
+	entries := s.searchIndex(func(slug string) string { return "#cmd-" + slug })
+	index, err := json.Marshal(entries)
+	if err != nil {
+		return fmt.Errorf("failed to marshal search index: %w", err)
+	}
+
+	data := s.page("", s.title)
+	data.InlineCSS = template.CSS(siteCSS)
+	data.InlineJS = template.JS("window.CMD4CODER_INDEX = " + string(index) + ";\n" + searchJS)
+	data.Categories = s.categories
+
+	return s.render(w, "single", data)
+}
+
+// ExportDir 实现 DirExporter，生成静态站点
+func (e HTMLExporter) ExportDir(dir string, commands []*model.Command, opts Options) error {
+	s := newSite(commands, opts)
+
+	for _, sub := range []string{"assets", "categories", "commands"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0755); err != nil {
+			return fmt.Errorf("failed to create directory: %w", err)
+		}
+	}
+
+	entries := s.searchIndex(func(slug string) string { return "commands/" + slug + ".html" })
+	index, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal search index: %w", err)
+	}
+
+	files := map[string]string{
+		"assets/site.css":   siteCSS,
+		"assets/search.js":  searchJS,
+		"search-index.json": string(index) + "\n",
+		// file:// 打开时无法 fetch JSON，同一份索引以脚本形式供页面加载
+		"search-index.js": "window.CMD4CODER_INDEX = " + string(index) + ";\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			return fmt.Errorf("failed to write file: %w", err)
+		}
+	}
+
+	home := s.page("", s.title)
+	home.Categories = s.categories
+	if err := s.renderFile(filepath.Join(dir, "index.html"), "index", home); err != nil {
+		return err
+	}
+
+	for _, category := range s.categories {
+		data := s.page("../", category.Name)
+		data.Category = category
+		if err := s.renderFile(filepath.Join(dir, "categories", category.Slug+".html"), "category", data); err != nil {
+			return err
+		}
+
+		for _, cmd := range category.Commands {
+			data := s.page("../", cmd.Name)
+			data.Category = category
+			data.Command = cmd
+			if err := s.renderFile(filepath.Join(dir, "commands", s.slugs[cmd.Name]+".html"), "command", data); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// site 生成 HTML 所需的数据
+type site struct {
+	opts       Options
+	labels     labels
+	title      string
+	total      int
+	categories []siteCategory
+	slugs      map[string]string // 命令名称 -> 页面文件名
+	tmpl       *template.Template
+}
+
+// siteCategory 站点中的一个分类
+type siteCategory struct {
+	Name     string
+	Slug     string
+	Commands []*model.Command
+}
+
+// searchEntry 前端搜索索引中的一条记录
+type searchEntry struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Category    string          `json:"category"`
+	Risk        model.RiskLevel `json:"risk,omitempty"`
+	URL         string          `json:"url"`
+}
+
+// pageData 模板数据
+type pageData struct {
+	Root       string // 页面到站点根目录的相对路径
+	Title      string
+	SiteTitle  string
+	Total      int
+	Labels     labels
+	Opts       Options
+	Categories []siteCategory
+	Category   siteCategory
+	Command    *model.Command
+	InlineCSS  template.CSS
+	InlineJS   template.JS
+}
+
+func newSite(commands []*model.Command, opts Options) *site {
+	s := &site{
+		opts:   opts,
+		labels: labelsFor(opts.Locale),
+		title:  opts.Title,
+		total:  len(commands),
+		slugs:  make(map[string]string, len(commands)),
+	}
+	if s.title == "" {
+		s.title = s.labels.Title
+	}
+
+	// 分类按首次出现的顺序排列
+	categoryIndex := make(map[string]int)
+	categorySlugs := newSlugSet()
+	commandSlugs := newSlugSet()
+	for _, cmd := range commands {
+		i, ok := categoryIndex[cmd.Category]
+		if !ok {
+			i = len(s.categories)
+			categoryIndex[cmd.Category] = i
+			s.categories = append(s.categories, siteCategory{Name: cmd.Category, Slug: categorySlugs.add(cmd.Category)})
+		}
+		s.categories[i].Commands = append(s.categories[i].Commands, cmd)
+		if _, ok := s.slugs[cmd.Name]; !ok {
+			s.slugs[cmd.Name] = commandSlugs.add(cmd.Name)
+		}
+	}
+
+	return s
+}
+
+func (s *site) page(root, title string) pageData {
+	return pageData{
+		Root:      root,
+		Title:     title,
+		SiteTitle: s.title,
+		Total:     s.total,
+		Labels:    s.labels,
+		Opts:      s.opts,
+	}
+}
+
+// searchIndex 生成搜索索引，url 把命令页面文件名转换为链接
+func (s *site) searchIndex(url func(slug string) string) []searchEntry {
+	entries := make([]searchEntry, 0, s.total)
+	for _, category := range s.categories {
+		for _, cmd := range category.Commands {
+			entries = append(entries, searchEntry{
+				Name:        cmd.Name,
+				Description: cmd.Description,
+				Category:    cmd.Category,
+				Risk:        s.risk(cmd),
+				URL:         url(s.slugs[cmd.Name]),
+			})
+		}
+	}
+	return entries
+}
+
+func (s *site) render(w io.Writer, name string, data pageData) error {
+	if s.tmpl == nil {
+		tmpl, err := template.New("site").Funcs(s.funcs()).Parse(siteTemplates)
+		if err != nil {
+			return fmt.Errorf("failed to parse template: %w", err)
+		}
+		s.tmpl = tmpl
+	}
+	if err := s.tmpl.ExecuteTemplate(w, name, data); err != nil {
+		return fmt.Errorf("failed to render %s: %w", name, err)
+	}
+	return nil
+}
+
+func (s *site) renderFile(filename, name string, data pageData) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer f.Close()
+
+	if err := s.render(f, name, data); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// risk 命令在页面中显示的风险级别；不包含风险或命令没有风险说明时为空，不显示标记
+func (s *site) risk(cmd *model.Command) model.RiskLevel {
+	if !s.opts.IncludeRisks || len(cmd.Risks) == 0 {
+		return ""
+	}
+	return cmd.GetHighestRisk()
+}
+
+func (s *site) funcs() template.FuncMap {
+	return template.FuncMap{
+		"slug": func(name string) string {
+			return s.slugs[name]
+		},
+		"riskLabel": func(level model.RiskLevel) string {
+			return s.labels.RiskLevels[level]
+		},
+		"risk": s.risk,
+		"join": strings.Join,
+	}
+}
+
+// slugSet 为名称生成不重复的文件名
+type slugSet map[string]bool
+
+func newSlugSet() slugSet {
+	return make(slugSet)
+}
+
+// add 生成名称对应的文件名，冲突时追加序号
+func (set slugSet) add(name string) string {
+	base := Slugify(name)
+	if base == "" {
+		base = "item"
+	}
+	slug := base
+	for i := 2; set[slug]; i++ {
+		slug = fmt.Sprintf("%s-%d", base, i)
+	}
+	set[slug] = true
+	return slug
+}
+
+// Slugify 把名称转为文件名：保留字母和数字（含中文），其余字符合并为连字符
+func Slugify(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			dash = false
+		} else if b.Len() > 0 && !dash {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
+
+// siteTemplates 站点页面模板
+const siteTemplates = `
+{{define "head"}}<!DOCTYPE html>
+<html lang="{{.Labels.Lang}}">
+<head>
+<meta charset="UTF-8">
+<meta name="viewport" content="width=device-width, initial-scale=1.0">
+<title>{{if ne .Title .SiteTitle}}{{.Title}} - {{end}}{{.SiteTitle}}</title>
+{{if .InlineCSS}}<style>{{.InlineCSS}}</style>{{else}}<link rel="stylesheet" href="{{.Root}}assets/site.css">{{end}}
+</head>
+<body>
+<header><a href="{{if .InlineCSS}}#{{else}}{{.Root}}index.html{{end}}">{{.SiteTitle}}</a></header>
+<main>
+{{end}}
+
+{{define "foot"}}</main>
+</body>
+</html>
+{{end}}
+
+{{define "badge"}}{{if .}}<span class="risk risk-{{.}}">{{riskLabel .}}</span>{{end}}{{end}}
+
+{{define "search"}}<div class="search">
+<input id="search" type="search" placeholder="{{.Labels.Search}}" autocomplete="off">
+<ul id="results" class="results hidden" data-empty="{{.Labels.NoResults}}"></ul>
+</div>
+{{end}}
+
+{{define "index"}}{{template "head" .}}<h1>{{.SiteTitle}}</h1>
+<p>{{.Labels.Total}}: {{.Total}}</p>
+{{template "search" .}}
+<h2>{{.Labels.Categories}}</h2>
+<ul class="category-list">
+{{- range .Categories}}
+<li><a href="categories/{{.Slug}}.html">{{.Name}}</a><span class="count">{{len .Commands}}</span></li>
+{{- end}}
+</ul>
+<script src="search-index.js"></script>
+<script src="assets/search.js"></script>
+{{template "foot" .}}{{end}}
+
+{{define "category"}}{{template "head" .}}<p class="breadcrumb"><a href="{{.Root}}index.html">{{.Labels.Home}}</a></p>
+<h1>{{.Category.Name}}</h1>
+<ul class="command-list">
+{{- range .Category.Commands}}
+<li><a href="{{$.Root}}commands/{{slug .Name}}.html">{{.Name}}</a> {{template "badge" (risk .)}}<span class="desc">{{.Description}}</span></li>
+{{- end}}
+</ul>
+{{template "foot" .}}{{end}}
+
+{{define "detail"}}{{$cmd := .Command}}<p>{{$cmd.Description}}</p>
+<p><strong>{{.Labels.Platforms}}</strong>: {{join $cmd.Platforms ", "}}</p>
+{{- if $cmd.InstallMethod}}
+<p><strong>{{.Labels.Install}}</strong>: {{$cmd.InstallMethod}}</p>
+{{- end}}
+{{- if $cmd.Usage}}
+<h3>{{.Labels.Usage}}</h3>
+{{- range $cmd.Usage}}
+<pre><code>{{.}}</code></pre>
+{{- end}}
+{{- end}}
+{{- if $cmd.Options}}
+<h3>{{.Labels.Options}}</h3>
+<table>
+{{- range $cmd.Options}}
+<tr><td><code>{{.Flag}}</code></td><td>{{.Description}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- if $cmd.Examples}}
+<h3>{{.Labels.Examples}}</h3>
+{{- range $cmd.Examples}}
+<p>{{.Description}}</p>
+<pre><code>{{.Command}}</code></pre>
+{{- if .Output}}
+<pre><code>{{.Output}}</code></pre>
+{{- end}}
+{{- end}}
+{{- end}}
+{{- if $cmd.Notes}}
+<h3>{{.Labels.Notes}}</h3>
+<ul>
+{{- range $cmd.Notes}}
+<li>{{.}}</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- if and .Opts.IncludeRisks $cmd.Risks}}
+<h3>{{.Labels.Risks}}</h3>
+<ul>
+{{- range $cmd.Risks}}
+<li>{{template "badge" .Level}} {{.Description}}</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- if $cmd.RelatedCommands}}
+<h3>{{.Labels.Related}}</h3>
+<ul>
+{{- range $cmd.RelatedCommands}}
+<li>{{if slug .}}<a href="{{if $.InlineCSS}}#cmd-{{slug .}}{{else}}{{$.Root}}commands/{{slug .}}.html{{end}}">{{.}}</a>{{else}}{{.}}{{end}}</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- if $cmd.References}}
+<h3>{{.Labels.References}}</h3>
+<ul>
+{{- range $cmd.References}}
+<li><a href="{{.}}" rel="noopener">{{.}}</a></li>
+{{- end}}
+</ul>
+{{- end}}
+{{end}}
+
+{{define "command"}}{{template "head" .}}<p class="breadcrumb"><a href="{{.Root}}index.html">{{.Labels.Home}}</a> › <a href="{{.Root}}categories/{{.Category.Slug}}.html">{{.Category.Name}}</a></p>
+<h1>{{.Command.Name}} {{template "badge" (risk .Command)}}</h1>
+{{template "detail" .}}
+{{template "foot" .}}{{end}}
+
+{{define "single"}}{{template "head" .}}<h1>{{.SiteTitle}}</h1>
+<p>{{.Labels.Total}}: {{.Total}}</p>
+{{template "search" .}}
+{{- range .Categories}}
+<h2 id="cat-{{.Slug}}">{{.Name}}</h2>
+{{- range .Commands}}
+<section id="cmd-{{slug .Name}}">
+<h3>{{.Name}} {{template "badge" (risk .)}}</h3>
+{{template "detail" ($.WithCommand .)}}
+</section>
+{{- end}}
+{{- end}}
+<script>{{.InlineJS}}</script>
+{{template "foot" .}}{{end}}
+`
+
+// WithCommand 返回指向另一个命令的模板数据，用于单页模式中逐个渲染命令
+func (p pageData) WithCommand(cmd *model.Command) pageData {
+	p.Command = cmd
+	return p
+}
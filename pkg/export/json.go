@@ -3,33 +3,60 @@ package export
 import (
 	"encoding/json"
 	"fmt"
-	"os"
+	"io"
 
 	"github.com/cmd4coder/cmd4coder/internal/model"
 )
 
-// ExportToJSON 导出命令到JSON格式
-func ExportToJSON(commands []*model.Command, filename string) error {
-	// 创建文件
-	f, err := os.Create(filename)
-	if err != nil {
-		return fmt.Errorf("failed to create file: %w", err)
+func init() {
+	Register("json", JSONExporter{})
+	Register("json-compact", JSONExporter{Compact: true})
+}
+
+// JSONExporter 导出为JSON
+//
+// 默认输出带版本和总数的格式化文档；Compact 时只输出紧凑的命令数组。
+type JSONExporter struct {
+	Compact bool
+}
+
+// Extension 实现 Exporter
+func (e JSONExporter) Extension() string {
+	return "json"
+}
+
+// Export 实现 Exporter，JSON 不分组，Title 非空时写入 title 字段
+func (e JSONExporter) Export(w io.Writer, commands []*model.Command, opts Options) error {
+	if !opts.IncludeRisks {
+		commands = withoutRisks(commands)
+	}
+
+	if e.Compact {
+		data, err := json.Marshal(commands)
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		if _, err := w.Write(data); err != nil {
+			return fmt.Errorf("failed to write JSON: %w", err)
+		}
+		return nil
 	}
-	defer f.Close()
 
 	// 创建导出结构
 	export := struct {
 		Version  string           `json:"version"`
+		Title    string           `json:"title,omitempty"`
 		Total    int              `json:"total"`
 		Commands []*model.Command `json:"commands"`
 	}{
 		Version:  "1.0.0",
+		Title:    opts.Title,
 		Total:    len(commands),
 		Commands: commands,
 	}
 
 	// 编码为JSON（格式化输出）
-	encoder := json.NewEncoder(f)
+	encoder := json.NewEncoder(w)
 	encoder.SetIndent("", "  ")
 
 	if err := encoder.Encode(export); err != nil {
@@ -39,16 +66,12 @@ func ExportToJSON(commands []*model.Command, filename string) error {
 	return nil
 }
 
+// ExportToJSON 导出命令到JSON格式
+func ExportToJSON(commands []*model.Command, filename string) error {
+	return ExportToFile(JSONExporter{}, commands, filename, DefaultOptions())
+}
+
 // ExportToJSONCompact 导出为紧凑的JSON格式
 func ExportToJSONCompact(commands []*model.Command, filename string) error {
-	data, err := json.Marshal(commands)
-	if err != nil {
-		return fmt.Errorf("failed to marshal JSON: %w", err)
-	}
-
-	if err := os.WriteFile(filename, data, 0644); err != nil {
-		return fmt.Errorf("failed to write file: %w", err)
-	}
-
-	return nil
+	return ExportToFile(JSONExporter{Compact: true}, commands, filename, DefaultOptions())
 }
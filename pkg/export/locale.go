@@ -0,0 +1,102 @@
+package export
+
+import "github.com/cmd4coder/cmd4coder/internal/model"
+
+// labels 导出文档中的固定文字
+type labels struct {
+	Lang        string // HTML lang 属性
+	Title       string
+	Total       string
+	Description string
+	Category    string
+	Categories  string
+	Contents    string
+	Platforms   string
+	Usage       string
+	Options     string
+	Examples    string
+	Output      string
+	Notes       string
+	Risks       string
+	Install     string
+	Related     string
+	References  string
+	Home        string
+	Search      string
+	NoResults   string
+	Omitted     string // 速查表超出页数时的说明，%d 为未列出的命令数
+	Reference   string // man 手册的简介，%s 为程序名
+	RiskLevels  map[model.RiskLevel]string
+}
+
+// locales 各语言的固定文字，未知语言使用中文
+var locales = map[string]labels{
+	"zh": {
+		Lang:        "zh-CN",
+		Title:       "命令行工具大全",
+		Total:       "总命令数",
+		Description: "描述",
+		Category:    "分类",
+		Categories:  "所有分类",
+		Contents:    "目录",
+		Platforms:   "平台",
+		Usage:       "使用方式",
+		Options:     "常用选项",
+		Examples:    "使用示例",
+		Output:      "输出",
+		Notes:       "注意事项",
+		Risks:       "风险说明",
+		Install:     "安装方法",
+		Related:     "相关命令",
+		References:  "参考链接",
+		Home:        "首页",
+		Search:      "搜索命令...",
+		NoResults:   "没有匹配的命令",
+		Omitted:     "另有 %d 个命令因页数限制未列出",
+		Reference:   "%s 命令速查",
+		RiskLevels: map[model.RiskLevel]string{
+			model.RiskLevelLow:      "低风险",
+			model.RiskLevelMedium:   "中风险",
+			model.RiskLevelHigh:     "高风险",
+			model.RiskLevelCritical: "严重风险",
+		},
+	},
+	"en": {
+		Lang:        "en",
+		Title:       "Command Reference",
+		Total:       "Total commands",
+		Description: "Description",
+		Category:    "Category",
+		Categories:  "Categories",
+		Contents:    "Contents",
+		Platforms:   "Platforms",
+		Usage:       "Usage",
+		Options:     "Options",
+		Examples:    "Examples",
+		Output:      "Output",
+		Notes:       "Notes",
+		Risks:       "Risks",
+		Install:     "Install",
+		Related:     "Related commands",
+		References:  "References",
+		Home:        "Home",
+		Search:      "Search commands...",
+		NoResults:   "No matching commands",
+		Omitted:     "%d more commands omitted to fit the page budget",
+		Reference:   "%s quick reference",
+		RiskLevels: map[model.RiskLevel]string{
+			model.RiskLevelLow:      "low",
+			model.RiskLevelMedium:   "medium",
+			model.RiskLevelHigh:     "high",
+			model.RiskLevelCritical: "critical",
+		},
+	},
+}
+
+// labelsFor 返回指定语言的固定文字
+func labelsFor(locale string) labels {
+	if l, ok := locales[locale]; ok {
+		return l
+	}
+	return locales["zh"]
+}
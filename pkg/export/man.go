@@ -0,0 +1,179 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cmd4coder/cmd4coder/internal/model"
+)
+
+func init() {
+	Register("man", ManExporter{})
+}
+
+// manSection 生成的 man 手册所在章节（7: 杂项）
+const manSection = "7"
+
+// ManExporter 导出为 roff 格式的 man 手册
+//
+// 每个程序一页，命名为 cmd4coder-<程序>.7，如 cmd4coder-kubectl.7 包含所有 kubectl 子命令。
+type ManExporter struct{}
+
+// Extension 实现 Exporter
+func (e ManExporter) Extension() string {
+	return manSection
+}
+
+// Export 实现 Exporter，所有命令写入同一页；只有一个程序时该页即为此程序的手册
+func (e ManExporter) Export(w io.Writer, commands []*model.Command, opts Options) error {
+	l := labelsFor(opts.Locale)
+	groups := groupByTool(commands)
+
+	var b strings.Builder
+	if len(groups) == 1 {
+		writeManPage(&b, groups[0], l, opts)
+	} else {
+		title := opts.Title
+		if title == "" {
+			title = l.Title
+		}
+		writeManHeader(&b, "cmd4coder", title)
+		for _, g := range groups {
+			fmt.Fprintf(&b, ".SH %s\n", roffQuote(g.Tool))
+			for _, cmd := range g.Commands {
+				writeManCommand(&b, cmd, ".SS", l, opts)
+			}
+		}
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("failed to write man page: %w", err)
+	}
+	return nil
+}
+
+// ExportDir 实现 DirExporter，按程序生成 man7/cmd4coder-<程序>.7
+//
+// 生成后执行 MANPATH=<dir>: man cmd4coder-kubectl 即可查看。
+func (e ManExporter) ExportDir(dir string, commands []*model.Command, opts Options) error {
+	l := labelsFor(opts.Locale)
+	pageDir := filepath.Join(dir, "man"+manSection)
+	if err := os.MkdirAll(pageDir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	slugs := newSlugSet()
+	for _, g := range groupByTool(commands) {
+		var b strings.Builder
+		writeManPage(&b, g, l, opts)
+		filename := filepath.Join(pageDir, "cmd4coder-"+slugs.add(g.Tool)+"."+manSection)
+		if err := os.WriteFile(filename, []byte(b.String()), 0644); err != nil {
+			return fmt.Errorf("failed to write file: %w", err)
+		}
+	}
+	return nil
+}
+
+// writeManPage 写出一个程序的手册页
+func writeManPage(b *strings.Builder, g toolGroup, l labels, opts Options) {
+	writeManHeader(b, "cmd4coder-"+Slugify(g.Tool), fmt.Sprintf(l.Reference, g.Tool))
+	for _, cmd := range g.Commands {
+		writeManCommand(b, cmd, ".SH", l, opts)
+	}
+}
+
+// writeManHeader 写出 .TH 和 NAME 段，不写日期以保证输出稳定
+func writeManHeader(b *strings.Builder, name, summary string) {
+	b.WriteString(".\\\" 由 cmd4coder 生成，请勿手动修改\n")
+	fmt.Fprintf(b, ".TH %s %s \"\" \"cmd4coder\" %s\n", roffQuote(strings.ToUpper(name)), manSection, roffQuote(summary))
+	b.WriteString(".SH NAME\n")
+	fmt.Fprintf(b, "%s \\- %s\n", roffEscape(name), roffEscape(summary))
+}
+
+// writeManCommand 写出单个命令，heading 为 .SH 或 .SS
+func writeManCommand(b *strings.Builder, cmd *model.Command, heading string, l labels, opts Options) {
+	fmt.Fprintf(b, "%s %s\n", heading, roffQuote(cmd.Name))
+	writeRoffText(b, cmd.Description)
+
+	if len(cmd.Usage) > 0 {
+		fmt.Fprintf(b, ".PP\n.B %s\n.RS\n.nf\n", roffQuote(l.Usage))
+		for _, usage := range cmd.Usage {
+			b.WriteString(roffLine(usage) + "\n")
+		}
+		b.WriteString(".fi\n.RE\n")
+	}
+
+	if len(cmd.Options) > 0 {
+		fmt.Fprintf(b, ".PP\n.B %s\n", roffQuote(l.Options))
+		for _, opt := range cmd.Options {
+			fmt.Fprintf(b, ".TP\n.B %s\n", roffQuote(opt.Flag))
+			writeRoffText(b, opt.Description)
+		}
+	}
+
+	if len(cmd.Examples) > 0 {
+		fmt.Fprintf(b, ".PP\n.B %s\n", roffQuote(l.Examples))
+		for _, ex := range cmd.Examples {
+			b.WriteString(".PP\n.RS\n")
+			if ex.Description != "" {
+				writeRoffText(b, ex.Description)
+			}
+			b.WriteString(".nf\n" + roffLine(ex.Command) + "\n.fi\n.RE\n")
+		}
+	}
+
+	if len(cmd.Notes) > 0 {
+		fmt.Fprintf(b, ".PP\n.B %s\n", roffQuote(l.Notes))
+		for _, note := range cmd.Notes {
+			b.WriteString(".IP \\(bu 2\n")
+			writeRoffText(b, note)
+		}
+	}
+
+	if opts.IncludeRisks && len(cmd.Risks) > 0 {
+		fmt.Fprintf(b, ".PP\n.B %s\n", roffQuote(l.Risks))
+		for _, risk := range cmd.Risks {
+			fmt.Fprintf(b, ".IP \\(bu 2\n[%s] ", roffEscape(l.RiskLevels[risk.Level]))
+			writeRoffText(b, risk.Description)
+		}
+	}
+
+	if cmd.InstallMethod != "" {
+		fmt.Fprintf(b, ".PP\n.B %s\n", roffQuote(l.Install))
+		writeRoffText(b, cmd.InstallMethod)
+	}
+
+	if len(cmd.RelatedCommands) > 0 {
+		fmt.Fprintf(b, ".PP\n.B %s\n", roffQuote(l.Related))
+		writeRoffText(b, strings.Join(cmd.RelatedCommands, ", "))
+	}
+}
+
+// writeRoffText 写出一段正文，每行都转义以免被当作请求
+func writeRoffText(b *strings.Builder, text string) {
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		b.WriteString(roffLine(line) + "\n")
+	}
+}
+
+// roffLine 转义一行正文，行首的 . 和 ' 前加零宽字符
+func roffLine(s string) string {
+	s = roffEscape(s)
+	if strings.HasPrefix(s, ".") || strings.HasPrefix(s, "'") {
+		s = `\&` + s
+	}
+	return s
+}
+
+// roffEscape 转义反斜杠和连字符
+func roffEscape(s string) string {
+	return strings.NewReplacer(`\`, `\e`, "-", `\-`).Replace(s)
+}
+
+// roffQuote 转义并加引号，用作请求的参数
+func roffQuote(s string) string {
+	return `"` + strings.ReplaceAll(roffEscape(s), `"`, `""`) + `"`
+}
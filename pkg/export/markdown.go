@@ -2,93 +2,369 @@ package export
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/cmd4coder/cmd4coder/internal/model"
 )
 
-// ExportToMarkdown 导出命令到Markdown格式
-func ExportToMarkdown(commands []*model.Command, filename string) error {
-	f, err := os.Create(filename)
-	if err != nil {
-		return fmt.Errorf("failed to create file: %w", err)
-	}
-	defer f.Close()
+func init() {
+	Register("markdown", MarkdownExporter{})
+}
 
-	// 写入标题
-	fmt.Fprintf(f, "# 命令行工具大全\n\n")
-	fmt.Fprintf(f, "总命令数: %d\n\n", len(commands))
-	fmt.Fprintf(f, "---\n\n")
+// markdownIndex 分拆导出时的目录文件名
+const markdownIndex = "README.md"
+
+// MarkdownExporter 导出为Markdown文档
+//
+// 文档开头生成目录，分类和命令带有锚点（cat-<slug>、cmd-<slug>，与 HTML 导出一致），
+// 相关命令链接到导出内容中的对应命令。ExportDir 按分类拆分为多个文件。
+type MarkdownExporter struct{}
 
-	// 按分类分组
-	categoryMap := make(map[string][]*model.Command)
+// Extension 实现 Exporter
+func (e MarkdownExporter) Extension() string {
+	return "md"
+}
+
+// markdownDoc 一次导出中分类和命令的锚点，用于目录和交叉链接
+type markdownDoc struct {
+	l        labels
+	opts     Options
+	split    bool              // 是否按分类拆分为多个文件
+	commands map[string]string // 命令名称 -> 锚点
+	files    map[string]string // 命令名称 -> 所在文件（仅拆分时）
+	catSlugs map[string]string // 分类 -> slug
+}
+
+// newMarkdownDoc 为命令分配锚点，分类和命令的 slug 在整个导出中唯一
+func newMarkdownDoc(commands []*model.Command, opts Options, split bool) *markdownDoc {
+	d := &markdownDoc{
+		l:        labelsFor(opts.Locale),
+		opts:     opts,
+		split:    split,
+		commands: make(map[string]string, len(commands)),
+		files:    make(map[string]string, len(commands)),
+		catSlugs: make(map[string]string),
+	}
+
+	catSlugs, cmdSlugs := newSlugSet(), newSlugSet()
 	for _, cmd := range commands {
-		categoryMap[cmd.Category] = append(categoryMap[cmd.Category], cmd)
+		if _, ok := d.catSlugs[cmd.Category]; !ok {
+			d.catSlugs[cmd.Category] = catSlugs.add(cmd.Category)
+		}
+		if _, ok := d.commands[cmd.Name]; !ok {
+			d.commands[cmd.Name] = "cmd-" + cmdSlugs.add(cmd.Name)
+			d.files[cmd.Name] = d.catSlugs[cmd.Category] + ".md"
+		}
 	}
+	return d
+}
 
-	// 遍历分类
-	for category, cmds := range categoryMap {
-		fmt.Fprintf(f, "## %s\n\n", category)
+// commandLink 命令的链接目标，from 为当前文件；命令不在导出内容中时返回空
+func (d *markdownDoc) commandLink(name, from string) string {
+	anchor, ok := d.commands[name]
+	if !ok {
+		return ""
+	}
+	if d.split && d.files[name] != from {
+		return d.files[name] + "#" + anchor
+	}
+	return "#" + anchor
+}
 
-		for _, cmd := range cmds {
-			fmt.Fprintf(f, "### %s\n\n", cmd.Name)
-			fmt.Fprintf(f, "**描述**: %s\n\n", cmd.Description)
+// title 文档标题
+func (d *markdownDoc) title() string {
+	if d.opts.Title != "" {
+		return d.opts.Title
+	}
+	return d.l.Title
+}
 
-			// 平台
-			fmt.Fprintf(f, "**平台**: %s\n\n", strings.Join(cmd.Platforms, ", "))
+// Export 实现 Exporter
+func (e MarkdownExporter) Export(w io.Writer, commands []*model.Command, opts Options) error {
+	d := newMarkdownDoc(commands, opts, false)
+	var b strings.Builder
 
-			// 使用方式
-			if len(cmd.Usage) > 0 {
-				fmt.Fprintf(f, "**使用方式**:\n")
-				for _, usage := range cmd.Usage {
-					fmt.Fprintf(f, "```\n%s\n```\n", usage)
-				}
-				fmt.Fprintf(f, "\n")
-			}
+	// 写入标题
+	fmt.Fprintf(&b, "# %s\n\n", mdEscape(d.title()))
+	fmt.Fprintf(&b, "%s: %d\n\n", d.l.Total, len(commands))
+
+	if opts.GroupBy == GroupByCategory {
+		groups := groupByCategory(commands)
 
-			// 选项
-			if len(cmd.Options) > 0 {
-				fmt.Fprintf(f, "**常用选项**:\n\n")
-				for _, opt := range cmd.Options {
-					fmt.Fprintf(f, "- `%s`: %s\n", opt.Flag, opt.Description)
-				}
-				fmt.Fprintf(f, "\n")
+		fmt.Fprintf(&b, "## %s\n\n", d.l.Contents)
+		for _, g := range groups {
+			fmt.Fprintf(&b, "- [%s](#cat-%s)\n", mdEscape(g.Category), d.catSlugs[g.Category])
+			for _, cmd := range g.Commands {
+				fmt.Fprintf(&b, "  - [%s](#%s)\n", mdEscape(cmd.Name), d.commands[cmd.Name])
 			}
+		}
+		fmt.Fprintf(&b, "\n---\n\n")
 
-			// 示例
-			if len(cmd.Examples) > 0 {
-				fmt.Fprintf(f, "**使用示例**:\n\n")
-				for i, example := range cmd.Examples {
-					fmt.Fprintf(f, "%d. %s\n", i+1, example.Description)
-					fmt.Fprintf(f, "   ```bash\n   %s\n   ```\n", example.Command)
-					if example.Output != "" {
-						fmt.Fprintf(f, "   输出:\n   ```\n   %s\n   ```\n", example.Output)
-					}
-				}
-				fmt.Fprintf(f, "\n")
+		for _, g := range groups {
+			fmt.Fprintf(&b, "## <a id=\"cat-%s\"></a>%s\n\n", d.catSlugs[g.Category], mdEscape(g.Category))
+			for _, cmd := range g.Commands {
+				d.writeCommand(&b, cmd, "###", "")
+				fmt.Fprintf(&b, "---\n\n")
 			}
+		}
+	} else {
+		fmt.Fprintf(&b, "## %s\n\n", d.l.Contents)
+		for _, cmd := range commands {
+			fmt.Fprintf(&b, "- [%s](#%s)\n", mdEscape(cmd.Name), d.commands[cmd.Name])
+		}
+		fmt.Fprintf(&b, "\n---\n\n")
+
+		for _, cmd := range commands {
+			d.writeCommand(&b, cmd, "##", "")
+			fmt.Fprintf(&b, "---\n\n")
+		}
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("failed to write markdown: %w", err)
+	}
+	return nil
+}
+
+// ExportDir 实现 DirExporter，每个分类一个文件，README.md 为总目录
+func (e MarkdownExporter) ExportDir(dir string, commands []*model.Command, opts Options) error {
+	d := newMarkdownDoc(commands, opts, true)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	groups := groupByCategory(commands)
+	files := make(map[string]string, len(groups)+1)
+
+	var index strings.Builder
+	fmt.Fprintf(&index, "# %s\n\n", mdEscape(d.title()))
+	fmt.Fprintf(&index, "%s: %d\n\n", d.l.Total, len(commands))
+	fmt.Fprintf(&index, "## %s\n\n", d.l.Categories)
+	for _, g := range groups {
+		fmt.Fprintf(&index, "- [%s](%s.md) (%d)\n", mdEscape(g.Category), d.catSlugs[g.Category], len(g.Commands))
+	}
+	files[markdownIndex] = index.String()
+
+	for _, g := range groups {
+		name := d.catSlugs[g.Category] + ".md"
+
+		var b strings.Builder
+		fmt.Fprintf(&b, "# %s\n\n", mdEscape(g.Category))
+		fmt.Fprintf(&b, "[%s](%s)\n\n", mdEscape(d.title()), markdownIndex)
+		fmt.Fprintf(&b, "## %s\n\n", d.l.Contents)
+		for _, cmd := range g.Commands {
+			fmt.Fprintf(&b, "- [%s](#%s)\n", mdEscape(cmd.Name), d.commands[cmd.Name])
+		}
+		fmt.Fprintf(&b, "\n---\n\n")
+		for _, cmd := range g.Commands {
+			d.writeCommand(&b, cmd, "##", name)
+			fmt.Fprintf(&b, "---\n\n")
+		}
+		files[name] = b.String()
+	}
+
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			return fmt.Errorf("failed to write file: %w", err)
+		}
+	}
+	return nil
+}
+
+// ExportToMarkdown 导出命令到Markdown格式
+func ExportToMarkdown(commands []*model.Command, filename string) error {
+	return ExportToFile(MarkdownExporter{}, commands, filename, DefaultOptions())
+}
 
-			// 风险说明
-			if len(cmd.Risks) > 0 {
-				fmt.Fprintf(f, "**风险说明**:\n\n")
-				for _, risk := range cmd.Risks {
-					emoji := getRiskEmoji(risk.Level)
-					fmt.Fprintf(f, "- %s **[%s]** %s\n", emoji, risk.Level, risk.Description)
-				}
-				fmt.Fprintf(f, "\n")
+// WriteCommandMarkdown 以Markdown格式写出单个命令
+func WriteCommandMarkdown(w io.Writer, cmd *model.Command) error {
+	var b strings.Builder
+	opts := DefaultOptions()
+	writeCommandMarkdown(&b, cmd, "###", labelsFor(opts.Locale), opts)
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("failed to write markdown: %w", err)
+	}
+	return nil
+}
+
+// writeCommandMarkdown 写出单个命令，heading 为命令标题的 Markdown 标记，不带锚点和链接
+func writeCommandMarkdown(b *strings.Builder, cmd *model.Command, heading string, l labels, opts Options) {
+	d := &markdownDoc{l: l, opts: opts}
+	d.writeCommand(b, cmd, heading, "")
+}
+
+// writeCommand 写出单个命令，file 为当前文件名，用于生成跨文件链接
+func (d *markdownDoc) writeCommand(b *strings.Builder, cmd *model.Command, heading, file string) {
+	l := d.l
+	if anchor, ok := d.commands[cmd.Name]; ok {
+		fmt.Fprintf(b, "%s <a id=\"%s\"></a>%s\n\n", heading, anchor, mdEscape(cmd.Name))
+	} else {
+		fmt.Fprintf(b, "%s %s\n\n", heading, mdEscape(cmd.Name))
+	}
+	fmt.Fprintf(b, "**%s**: %s\n\n", l.Description, mdEscape(cmd.Description))
+
+	// 平台
+	fmt.Fprintf(b, "**%s**: %s\n\n", l.Platforms, mdEscape(strings.Join(cmd.Platforms, ", ")))
+
+	// 使用方式
+	if len(cmd.Usage) > 0 {
+		fmt.Fprintf(b, "**%s**:\n", l.Usage)
+		for _, usage := range cmd.Usage {
+			b.WriteString(mdFence(usage, "", ""))
+		}
+		fmt.Fprintf(b, "\n")
+	}
+
+	// 选项
+	if len(cmd.Options) > 0 {
+		fmt.Fprintf(b, "**%s**:\n\n", l.Options)
+		for _, opt := range cmd.Options {
+			fmt.Fprintf(b, "- %s: %s\n", mdCode(opt.Flag), mdEscape(opt.Description))
+		}
+		fmt.Fprintf(b, "\n")
+	}
+
+	// 示例
+	if len(cmd.Examples) > 0 {
+		fmt.Fprintf(b, "**%s**:\n\n", l.Examples)
+		for i, example := range cmd.Examples {
+			fmt.Fprintf(b, "%d. %s\n", i+1, mdEscape(example.Description))
+			b.WriteString(mdFence(example.Command, "bash", "   "))
+			if example.Output != "" {
+				fmt.Fprintf(b, "   %s:\n", l.Output)
+				b.WriteString(mdFence(example.Output, "", "   "))
 			}
+		}
+		fmt.Fprintf(b, "\n")
+	}
+
+	// 注意事项
+	if len(cmd.Notes) > 0 {
+		fmt.Fprintf(b, "**%s**:\n\n", l.Notes)
+		for _, note := range cmd.Notes {
+			fmt.Fprintf(b, "- %s\n", mdEscape(note))
+		}
+		fmt.Fprintf(b, "\n")
+	}
+
+	// 风险说明
+	if d.opts.IncludeRisks && len(cmd.Risks) > 0 {
+		fmt.Fprintf(b, "**%s**:\n\n", l.Risks)
+		for _, risk := range cmd.Risks {
+			emoji := getRiskEmoji(risk.Level)
+			fmt.Fprintf(b, "- %s **[%s]** %s\n", emoji, risk.Level, mdEscape(risk.Description))
+		}
+		fmt.Fprintf(b, "\n")
+	}
+
+	// 安装方法
+	if cmd.InstallMethod != "" {
+		fmt.Fprintf(b, "**%s**: %s\n\n", l.Install, mdEscape(cmd.InstallMethod))
+	}
 
-			// 安装方法
-			if cmd.InstallMethod != "" {
-				fmt.Fprintf(f, "**安装方法**: %s\n\n", cmd.InstallMethod)
+	// 相关命令，导出内容中有的命令链接到对应锚点
+	if len(cmd.RelatedCommands) > 0 {
+		related := make([]string, 0, len(cmd.RelatedCommands))
+		for _, name := range cmd.RelatedCommands {
+			if link := d.commandLink(name, file); link != "" {
+				related = append(related, fmt.Sprintf("[%s](%s)", mdEscape(name), link))
+			} else {
+				related = append(related, mdCode(name))
 			}
+		}
+		fmt.Fprintf(b, "**%s**: %s\n\n", l.Related, strings.Join(related, ", "))
+	}
 
-			fmt.Fprintf(f, "---\n\n")
+	// 参考链接
+	if len(cmd.References) > 0 {
+		fmt.Fprintf(b, "**%s**:\n\n", l.References)
+		for _, ref := range cmd.References {
+			fmt.Fprintf(b, "- <%s>\n", strings.NewReplacer("<", "%3C", ">", "%3E", " ", "%20").Replace(ref))
 		}
+		fmt.Fprintf(b, "\n")
 	}
+}
 
-	return nil
+// categoryGroup 同一分类的命令
+type categoryGroup struct {
+	Category string
+	Commands []*model.Command
+}
+
+// groupByCategory 按分类分组，分类按首次出现的顺序排列
+func groupByCategory(commands []*model.Command) []categoryGroup {
+	var groups []categoryGroup
+	index := make(map[string]int)
+	for _, cmd := range commands {
+		i, ok := index[cmd.Category]
+		if !ok {
+			i = len(groups)
+			index[cmd.Category] = i
+			groups = append(groups, categoryGroup{Category: cmd.Category})
+		}
+		groups[i].Commands = append(groups[i].Commands, cmd)
+	}
+	return groups
+}
+
+// mdEscape 转义行内文本中的 Markdown 标记，换行替换为空格
+func mdEscape(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	var b strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune("\\`*_[]<>|#", r) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// mdCode 生成行内代码，内容含反引号时使用更长的反引号包裹
+func mdCode(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	fence := strings.Repeat("`", longestRun(s, '`')+1)
+	if strings.HasPrefix(s, "`") || strings.HasSuffix(s, "`") {
+		s = " " + s + " "
+	}
+	return fence + s + fence
+}
+
+// mdFence 生成代码块，每行加上缩进 indent；内容含 ``` 时使用更长的围栏
+func mdFence(s, lang, indent string) string {
+	n := longestRun(s, '`') + 1
+	if n < 3 {
+		n = 3
+	}
+	fence := strings.Repeat("`", n)
+
+	var b strings.Builder
+	b.WriteString(indent + fence + lang + "\n")
+	for _, line := range strings.Split(strings.TrimRight(s, "\n"), "\n") {
+		b.WriteString(indent + line + "\n")
+	}
+	b.WriteString(indent + fence + "\n")
+	return b.String()
+}
+
+// longestRun 字符 c 连续出现的最大次数
+func longestRun(s string, c rune) int {
+	longest, run := 0, 0
+	for _, r := range s {
+		if r == c {
+			run++
+			if run > longest {
+				longest = run
+			}
+		} else {
+			run = 0
+		}
+	}
+	return longest
 }
 
 func getRiskEmoji(level model.RiskLevel) string {
@@ -0,0 +1,44 @@
+# 由 cmd4coder 生成，放到 ~/.config/fish/conf.d/ 下
+
+# __cmd4coder_has_completion 程序是否已有补全文件
+function __cmd4coder_has_completion
+    for dir in $fish_complete_path
+        test -e $dir/$argv[1].fish; and return 0
+    end
+    return 1
+end
+
+# __cmd4coder_path 已输入的子命令路径（忽略选项）是否等于参数；--prefix 时判断是否以参数开头
+function __cmd4coder_path
+    set -l prefix 0
+    if test "$argv[1]" = --prefix
+        set prefix 1
+        set -e argv[1]
+    end
+    set -l path
+    for word in (commandline -opc)[2..-1]
+        string match -q -- '-*' $word; or set -a path $word
+    end
+    if test $prefix = 1
+        test (count $argv) -eq 0; and return 0
+        test "$path[1..(count $argv)]" = "$argv"
+    else
+        test "$path" = "$argv"
+    end
+end
+
+if not __cmd4coder_has_completion 'ls'
+    complete -c 'ls' -n '__cmd4coder_path --prefix' -s 'l' -d '长格式显示'
+    complete -c 'ls' -n '__cmd4coder_path --prefix' -s 'a' -l 'all' -d '显示隐藏文件'
+end
+
+if not __cmd4coder_has_completion 'rm'
+    complete -c 'rm' -n '__cmd4coder_path --prefix' -s 'r' -s 'R' -d '递归删除'
+    complete -c 'rm' -n '__cmd4coder_path --prefix' -s 'f' -l 'force' -d '强制删除'
+end
+
+if not __cmd4coder_has_completion 'git'
+    complete -c 'git' -f -n '__cmd4coder_path' -a 'stash'
+    complete -c 'git' -f -n '__cmd4coder_path \'stash\'' -a 'pop' -d '恢复最近一次贮藏'
+    complete -c 'git' -n '__cmd4coder_path --prefix \'stash\' \'pop\'' -l 'index' -d '同时恢复暂存区'
+end
@@ -0,0 +1,314 @@
+// Package history 解析 bash/zsh/fish 的 shell 历史文件
+package history
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Format 历史文件格式
+type Format string
+
+const (
+	FormatAuto Format = "auto" // 根据文件名和内容自动识别
+	FormatBash Format = "bash" // 每行一条，可能带 #时间戳 行
+	FormatZsh  Format = "zsh"  // EXTENDED_HISTORY: ": 1700000000:0;command"
+	FormatFish Format = "fish" // "- cmd: command" / "  when: 1700000000"
+)
+
+// IsValid 检查格式是否有效
+func (f Format) IsValid() bool {
+	switch f {
+	case FormatAuto, FormatBash, FormatZsh, FormatFish:
+		return true
+	default:
+		return false
+	}
+}
+
+// Invocation 一次命令调用（复合命令已按 && || ; | 拆开）
+type Invocation struct {
+	Program string   // 程序名（已去掉 sudo/env 等前缀和路径）
+	Args    []string // 参数
+	Line    string   // 原始命令行
+}
+
+// Tokens 程序名和参数
+func (inv Invocation) Tokens() []string {
+	return append([]string{inv.Program}, inv.Args...)
+}
+
+// ReadFile 读取历史文件，返回其中的命令行
+func ReadFile(path string, format Format) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history file: %w", err)
+	}
+	defer f.Close()
+
+	if format == FormatAuto || format == "" {
+		format = detectFormat(path)
+	}
+
+	return Read(f, format)
+}
+
+// Read 按指定格式读取命令行；FormatAuto 时根据内容识别
+func Read(r io.Reader, format Format) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var lines []string
+	var pending strings.Builder
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if format == FormatAuto || format == "" {
+			format = detectLine(line)
+		}
+
+		switch format {
+		case FormatFish:
+			trimmed := strings.TrimSpace(line)
+			if strings.HasPrefix(trimmed, "- cmd: ") {
+				cmd := strings.TrimPrefix(trimmed, "- cmd: ")
+				cmd = strings.ReplaceAll(cmd, `\n`, "\n")
+				cmd = strings.ReplaceAll(cmd, `\\`, `\`)
+				lines = append(lines, cmd)
+			}
+			continue
+
+		case FormatZsh:
+			if pending.Len() == 0 && strings.HasPrefix(line, ": ") {
+				if i := strings.Index(line, ";"); i > 0 {
+					line = line[i+1:]
+				}
+			}
+
+		default:
+			// HISTTIMEFORMAT 写入的时间戳行
+			if pending.Len() == 0 && isTimestampLine(line) {
+				continue
+			}
+		}
+
+		// 反斜杠续行
+		if strings.HasSuffix(line, `\`) {
+			pending.WriteString(strings.TrimSuffix(line, `\`))
+			pending.WriteString(" ")
+			continue
+		}
+		pending.WriteString(line)
+		if cmd := strings.TrimSpace(pending.String()); cmd != "" {
+			lines = append(lines, cmd)
+		}
+		pending.Reset()
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read history: %w", err)
+	}
+
+	return lines, nil
+}
+
+// detectFormat 根据文件名识别格式
+func detectFormat(path string) Format {
+	base := filepath.Base(path)
+	switch {
+	case strings.Contains(base, "fish"):
+		return FormatFish
+	case strings.Contains(base, "zsh") || strings.Contains(base, "zhistory"):
+		return FormatZsh
+	case strings.Contains(base, "bash"):
+		return FormatBash
+	default:
+		return FormatAuto
+	}
+}
+
+// detectLine 根据首行内容识别格式
+func detectLine(line string) Format {
+	switch {
+	case strings.HasPrefix(strings.TrimSpace(line), "- cmd: "):
+		return FormatFish
+	case strings.HasPrefix(line, ": ") && strings.Contains(line, ";"):
+		return FormatZsh
+	default:
+		return FormatBash
+	}
+}
+
+// isTimestampLine 判断是否为 "#1700000000" 形式的时间戳行
+func isTimestampLine(line string) bool {
+	if len(line) < 2 || line[0] != '#' {
+		return false
+	}
+	for _, c := range line[1:] {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// wrapperPrefixes 不计入程序名的前缀命令及其带参数值的选项
+//
+// 选项是否带值因命令而异：nice -n 10 的 -n 带值，sudo -n（非交互）则不带。
+var wrapperPrefixes = map[string]map[string]bool{
+	"sudo": {
+		"-u": true, "-g": true, "-C": true, "-D": true, "-h": true, "-p": true, "-r": true, "-t": true, "-U": true,
+		"--user": true, "--group": true, "--close-from": true, "--chdir": true, "--host": true, "--prompt": true,
+		"--role": true, "--type": true, "--other-user": true,
+	},
+	"doas":    {"-u": true, "-C": true},
+	"time":    {"-f": true, "-o": true, "--format": true, "--output": true},
+	"nohup":   {},
+	"env":     {"-u": true, "-C": true, "-S": true, "--unset": true, "--chdir": true, "--split-string": true},
+	"exec":    {"-a": true},
+	"command": {},
+	"builtin": {},
+	"nice":    {"-n": true, "--adjustment": true},
+	"watch":   {"-n": true, "--interval": true},
+}
+
+// Parse 把命令行拆分为调用：按 && || ; | 拆开复合命令，去掉 sudo、环境变量赋值等前缀
+func Parse(line string) []Invocation {
+	var invocations []Invocation
+
+	for _, segment := range splitCompound(line) {
+		tokens := stripWrappers(splitWords(segment))
+		if len(tokens) == 0 {
+			continue
+		}
+
+		invocations = append(invocations, Invocation{
+			Program: filepath.Base(tokens[0]),
+			Args:    tokens[1:],
+			Line:    segment,
+		})
+	}
+
+	return invocations
+}
+
+// stripWrappers 去掉 sudo/env 等前缀命令及其选项和环境变量赋值
+func stripWrappers(tokens []string) []string {
+	// valueOptions 当前前缀命令带参数值的选项，nil 表示还没有遇到前缀命令
+	var valueOptions map[string]bool
+	for len(tokens) > 0 {
+		first := tokens[0]
+		if options, ok := wrapperPrefixes[first]; ok {
+			valueOptions = options
+			tokens = tokens[1:]
+			continue
+		}
+		switch {
+		case isAssignment(first):
+		case valueOptions != nil && strings.HasPrefix(first, "-"):
+			if valueOptions[first] && len(tokens) > 1 {
+				tokens = tokens[1:]
+			}
+		default:
+			return tokens
+		}
+		tokens = tokens[1:]
+	}
+	return tokens
+}
+
+// isAssignment 判断是否为 FOO=bar 形式的环境变量赋值
+func isAssignment(token string) bool {
+	i := strings.Index(token, "=")
+	if i <= 0 {
+		return false
+	}
+	for _, c := range token[:i] {
+		if !(c == '_' || c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z' || c >= '0' && c <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// splitCompound 按 && || ; | 拆分复合命令，忽略引号内的分隔符
+func splitCompound(line string) []string {
+	var segments []string
+	var cur strings.Builder
+	var quote byte
+
+	flush := func() {
+		if s := strings.TrimSpace(cur.String()); s != "" {
+			segments = append(segments, s)
+		}
+		cur.Reset()
+	}
+
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+			cur.WriteByte(c)
+		case c == '\'' || c == '"':
+			quote = c
+			cur.WriteByte(c)
+		case c == ';' || c == '|' || c == '\n':
+			flush()
+			if c == '|' && i+1 < len(line) && line[i+1] == '|' {
+				i++
+			}
+		case c == '&' && i+1 < len(line) && line[i+1] == '&':
+			flush()
+			i++
+		default:
+			cur.WriteByte(c)
+		}
+	}
+	flush()
+
+	return segments
+}
+
+// splitWords 按空白拆分单词，引号内的空白不拆分，并去掉引号
+func splitWords(segment string) []string {
+	var words []string
+	var cur strings.Builder
+	var quote byte
+	inWord := false
+
+	for i := 0; i < len(segment); i++ {
+		c := segment[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			} else {
+				cur.WriteByte(c)
+			}
+		case c == '\'' || c == '"':
+			quote = c
+			inWord = true
+		case c == ' ' || c == '\t':
+			if inWord {
+				words = append(words, cur.String())
+				cur.Reset()
+				inWord = false
+			}
+		default:
+			cur.WriteByte(c)
+			inWord = true
+		}
+	}
+	if inWord {
+		words = append(words, cur.String())
+	}
+
+	return words
+}
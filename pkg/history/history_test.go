@@ -0,0 +1,119 @@
+package history
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRead(t *testing.T) {
+	tests := []struct {
+		name   string
+		format Format
+		input  string
+		want   []string
+	}{
+		{
+			name:   "bash with timestamps",
+			format: FormatBash,
+			input:  "#1700000000\nls -la\n#1700000001\ngit status\n",
+			want:   []string{"ls -la", "git status"},
+		},
+		{
+			name:   "zsh extended history",
+			format: FormatZsh,
+			input:  ": 1700000000:0;kubectl get pods\n: 1700000001:3;docker build \\\n  -t app .\n",
+			want:   []string{"kubectl get pods", "docker build    -t app ."},
+		},
+		{
+			name:   "fish history",
+			format: FormatFish,
+			input:  "- cmd: git log --oneline\n  when: 1700000000\n- cmd: echo a\\nls\n  when: 1700000001\n  paths:\n    - a\n",
+			want:   []string{"git log --oneline", "echo a\nls"},
+		},
+		{
+			name:   "auto detect zsh",
+			format: FormatAuto,
+			input:  ": 1700000000:0;make test\n",
+			want:   []string{"make test"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Read(strings.NewReader(tt.input), tt.format)
+			if err != nil {
+				t.Fatalf("Read() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Read() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		line     string
+		programs []string
+		args     [][]string
+	}{
+		{
+			line:     "sudo -u postgres psql -c 'select 1'",
+			programs: []string{"psql"},
+			args:     [][]string{{"-c", "select 1"}},
+		},
+		{
+			line:     "KUBECONFIG=~/.kube/prod kubectl get pods -n app | grep Running",
+			programs: []string{"kubectl", "grep"},
+			args:     [][]string{{"get", "pods", "-n", "app"}, {"Running"}},
+		},
+		{
+			line:     "sudo -n apt install -y curl",
+			programs: []string{"apt"},
+			args:     [][]string{{"install", "-y", "curl"}},
+		},
+		{
+			line:     "nice -n 10 make -j4",
+			programs: []string{"make"},
+			args:     [][]string{{"-j4"}},
+		},
+		{
+			line:     "sudo -E nice -n 5 env -u HOME LANG=C ionice -c3 rsync -a src dst",
+			programs: []string{"ionice"},
+			args:     [][]string{{"-c3", "rsync", "-a", "src", "dst"}},
+		},
+		{
+			line:     "watch -n 2 kubectl get pods",
+			programs: []string{"kubectl"},
+			args:     [][]string{{"get", "pods"}},
+		},
+		{
+			line:     "time -f %e sudo --user=deploy ./deploy.sh",
+			programs: []string{"deploy.sh"},
+			args:     [][]string{{}},
+		},
+		{
+			line:     "cd /tmp && /usr/bin/tar xzf a.tgz; echo 'a && b' || true",
+			programs: []string{"cd", "tar", "echo", "true"},
+			args:     [][]string{{"/tmp"}, {"xzf", "a.tgz"}, {"a && b"}, {}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			got := Parse(tt.line)
+			if len(got) != len(tt.programs) {
+				t.Fatalf("Parse() returned %d invocations, want %d: %+v", len(got), len(tt.programs), got)
+			}
+			for i, inv := range got {
+				if inv.Program != tt.programs[i] {
+					t.Errorf("invocation %d program = %q, want %q", i, inv.Program, tt.programs[i])
+				}
+				if len(inv.Args) != len(tt.args[i]) || (len(inv.Args) > 0 && !reflect.DeepEqual(inv.Args, tt.args[i])) {
+					t.Errorf("invocation %d args = %q, want %q", i, inv.Args, tt.args[i])
+				}
+			}
+		})
+	}
+}
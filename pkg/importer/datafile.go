@@ -0,0 +1,260 @@
+package importer
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+
+	"github.com/cmd4coder/cmd4coder/internal/model"
+	"gopkg.in/yaml.v3"
+)
+
+// DataFile 可写回的命令数据文件
+//
+// 基于 yaml.Node 编辑，写回时保留引号风格以及模型之外的字段（如 version_check），
+// commands 之前的文件头（注释、空行）按原文保留，只有被修改的命令条目会发生变化，便于在 git 中审查。
+type DataFile struct {
+	// Path 相对数据目录的路径，与 metadata.yaml 中 data_files 一致
+	Path string
+
+	root     yaml.Node
+	list     *model.CommandList
+	head     []byte // 原文件中 commands 键之前的内容，新文件为 nil
+	modified bool
+}
+
+// NewDataFile 创建新的空数据文件
+func NewDataFile(path, category, description string) *DataFile {
+	f := &DataFile{
+		Path: path,
+		list: &model.CommandList{
+			Category:    category,
+			Description: description,
+		},
+		modified: true,
+	}
+
+	f.root.Kind = yaml.DocumentNode
+	f.root.Content = []*yaml.Node{{Kind: yaml.MappingNode}}
+	setMappingValue(f.root.Content[0], "category", quotedString(category))
+	setMappingValue(f.root.Content[0], "description", quotedString(description))
+	setMappingValue(f.root.Content[0], "commands", &yaml.Node{Kind: yaml.SequenceNode})
+
+	return f
+}
+
+// LoadDataFile 从数据目录加载数据文件
+func LoadDataFile(dataDir, path string) (*DataFile, error) {
+	fullPath := filepath.Join(dataDir, path)
+	data, err := os.ReadFile(fullPath)
+	if err != nil {
+		return nil, model.ErrDataLoadFailed{File: fullPath, Err: err}
+	}
+
+	f := &DataFile{Path: path}
+	if err := yaml.Unmarshal(data, &f.root); err != nil {
+		return nil, model.ErrDataLoadFailed{File: fullPath, Err: err}
+	}
+
+	var list model.CommandList
+	if err := f.root.Decode(&list); err != nil {
+		return nil, model.ErrDataLoadFailed{File: fullPath, Err: err}
+	}
+	f.list = &list
+
+	if loc := commandsKeyPattern.FindIndex(data); loc != nil {
+		f.head = data[:loc[0]]
+	}
+
+	return f, nil
+}
+
+// Category 数据文件的分类
+func (f *DataFile) Category() string {
+	return f.list.Category
+}
+
+// Commands 数据文件中的命令
+func (f *DataFile) Commands() []*model.Command {
+	return f.list.Commands
+}
+
+// Modified 是否有未保存的修改
+func (f *DataFile) Modified() bool {
+	return f.modified
+}
+
+// PutCommand 写入命令：同名命令只替换模型中的字段，其余字段保持原样；不存在时追加到末尾
+func (f *DataFile) PutCommand(cmd *model.Command) error {
+	var encoded yaml.Node
+	if err := encoded.Encode(cmd); err != nil {
+		return fmt.Errorf("failed to encode command %s: %w", cmd.Name, err)
+	}
+	quoteStringValues(&encoded)
+	dropZeroValues(&encoded)
+
+	commands := mappingValue(f.root.Content[0], "commands")
+	if commands == nil {
+		commands = &yaml.Node{Kind: yaml.SequenceNode}
+		setMappingValue(f.root.Content[0], "commands", commands)
+	}
+
+	f.modified = true
+
+	for i, item := range commands.Content {
+		name := mappingValue(item, "name")
+		if name == nil || name.Value != cmd.Name {
+			continue
+		}
+
+		for j := 0; j+1 < len(encoded.Content); j += 2 {
+			key, value := encoded.Content[j].Value, encoded.Content[j+1]
+			if !nodesEqual(mappingValue(item, key), value) {
+				setMappingValue(item, key, value)
+			}
+		}
+		f.list.Commands[i] = cmd
+		return nil
+	}
+
+	commands.Content = append(commands.Content, &encoded)
+	f.list.Commands = append(f.list.Commands, cmd)
+	return nil
+}
+
+// commandItemPattern 命令列表项的起始行，连同紧挨在它前面的注释行
+var commandItemPattern = regexp.MustCompile(`(?m)^((?:  #.*\n)*  - )`)
+
+// commandsKeyPattern 顶层 commands 键所在行
+var commandsKeyPattern = regexp.MustCompile(`(?m)^commands:`)
+
+// Save 写回数据目录
+func (f *DataFile) Save(dataDir string) error {
+	data, err := encodeYAML(&f.root)
+	if err != nil {
+		return err
+	}
+
+	// 与手工维护的数据文件保持一致：命令之间空一行
+	out := commandItemPattern.ReplaceAll(data, []byte("\n$1"))
+	out = bytes.Replace(out, []byte("commands:\n\n"), []byte("commands:\n"), 1)
+
+	// 文件头只含分类和描述，导入不会修改，按原文保留
+	if loc := commandsKeyPattern.FindIndex(out); f.head != nil && loc != nil {
+		out = append(append([]byte{}, f.head...), out[loc[0]:]...)
+	}
+
+	fullPath := filepath.Join(dataDir, f.Path)
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := os.WriteFile(fullPath, out, 0644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	f.modified = false
+	return nil
+}
+
+// encodeYAML 按数据文件的缩进风格（2空格）编码
+func encodeYAML(node *yaml.Node) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(node); err != nil {
+		return nil, fmt.Errorf("failed to encode YAML: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode YAML: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// mappingValue 获取映射节点中指定键的值
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// setMappingValue 设置映射节点中指定键的值，不存在时追加
+func setMappingValue(node *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content[i+1] = value
+			return
+		}
+	}
+	node.Content = append(node.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+		value,
+	)
+}
+
+// dropZeroValues 去掉映射中的零值字段（false、空列表、空字符串），避免写入无意义的键
+func dropZeroValues(node *yaml.Node) {
+	content := node.Content[:0]
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		value := node.Content[i+1]
+		if isZeroNode(value) {
+			continue
+		}
+		content = append(content, node.Content[i], value)
+	}
+	node.Content = content
+}
+
+// isZeroNode 判断节点是否为零值
+func isZeroNode(node *yaml.Node) bool {
+	switch node.Kind {
+	case yaml.SequenceNode, yaml.MappingNode:
+		return len(node.Content) == 0
+	case yaml.ScalarNode:
+		return node.Tag == "!!null" || node.Value == "" || (node.Tag == "!!bool" && node.Value == "false")
+	}
+	return false
+}
+
+// nodesEqual 按解码后的值比较两个节点，忽略引号等格式差异
+func nodesEqual(a, b *yaml.Node) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	var va, vb interface{}
+	if a.Decode(&va) != nil || b.Decode(&vb) != nil {
+		return false
+	}
+	return reflect.DeepEqual(va, vb)
+}
+
+// quotedString 创建双引号风格的字符串节点
+func quotedString(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Style: yaml.DoubleQuotedStyle, Value: value}
+}
+
+// quoteStringValues 字符串值统一使用双引号，与现有数据文件风格一致
+func quoteStringValues(node *yaml.Node) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 1; i < len(node.Content); i += 2 {
+			quoteStringValues(node.Content[i])
+		}
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			quoteStringValues(item)
+		}
+	case yaml.ScalarNode:
+		if node.Tag == "!!str" {
+			node.Style = yaml.DoubleQuotedStyle
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package importer
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/cmd4coder/cmd4coder/internal/model"
+	"gopkg.in/yaml.v3"
+)
+
+// Dataset 可写回的数据集，按 metadata.yaml 中的 data_files 加载全部数据文件
+type Dataset struct {
+	dataDir     string
+	metadata    yaml.Node
+	metadataRaw []byte // metadata.yaml 原文，新增数据文件时按行插入以保留格式
+	newFiles    []string
+
+	files  []*DataFile
+	byName map[string]*DataFile
+}
+
+// LoadDataset 加载数据目录
+func LoadDataset(dataDir string) (*Dataset, error) {
+	metadataPath := filepath.Join(dataDir, "metadata.yaml")
+	data, err := os.ReadFile(metadataPath)
+	if err != nil {
+		return nil, model.ErrDataLoadFailed{File: metadataPath, Err: err}
+	}
+
+	d := &Dataset{
+		dataDir:     dataDir,
+		metadataRaw: data,
+		byName:      make(map[string]*DataFile),
+	}
+	if err := yaml.Unmarshal(data, &d.metadata); err != nil {
+		return nil, model.ErrDataLoadFailed{File: metadataPath, Err: err}
+	}
+
+	var metadata model.Metadata
+	if err := d.metadata.Decode(&metadata); err != nil {
+		return nil, model.ErrDataLoadFailed{File: metadataPath, Err: err}
+	}
+
+	for _, path := range metadata.DataFiles {
+		f, err := LoadDataFile(dataDir, path)
+		if err != nil {
+			return nil, err
+		}
+		d.addFile(f)
+	}
+
+	return d, nil
+}
+
+// addFile 登记数据文件并建立名称索引
+func (d *Dataset) addFile(f *DataFile) {
+	d.files = append(d.files, f)
+	for _, cmd := range f.Commands() {
+		d.byName[cmd.Name] = f
+	}
+}
+
+// Files 全部数据文件
+func (d *Dataset) Files() []*DataFile {
+	return d.files
+}
+
+// Lookup 按名称查找命令及其所在数据文件
+func (d *Dataset) Lookup(name string) (*model.Command, *DataFile) {
+	f, ok := d.byName[name]
+	if !ok {
+		return nil, nil
+	}
+	for _, cmd := range f.Commands() {
+		if cmd.Name == name {
+			return cmd, f
+		}
+	}
+	return nil, nil
+}
+
+// File 获取指定路径的数据文件，不存在时创建并登记到 metadata.yaml
+func (d *Dataset) File(path, category, description string) *DataFile {
+	for _, f := range d.files {
+		if f.Path == path {
+			return f
+		}
+	}
+
+	f := NewDataFile(path, category, description)
+	d.addFile(f)
+	d.newFiles = append(d.newFiles, path)
+	return f
+}
+
+// FileForCategory 查找分类对应的数据文件
+func (d *Dataset) FileForCategory(category string) *DataFile {
+	for _, f := range d.files {
+		if f.Category() == category {
+			return f
+		}
+	}
+	return nil
+}
+
+// Put 写入命令到指定数据文件并更新名称索引
+func (d *Dataset) Put(f *DataFile, cmd *model.Command) error {
+	if err := f.PutCommand(cmd); err != nil {
+		return err
+	}
+	d.byName[cmd.Name] = f
+	return nil
+}
+
+// Save 写回所有修改过的数据文件，新文件同时追加到 metadata.yaml 的 data_files
+func (d *Dataset) Save() ([]string, error) {
+	var saved []string
+	for _, f := range d.files {
+		if !f.Modified() {
+			continue
+		}
+		if err := f.Save(d.dataDir); err != nil {
+			return saved, err
+		}
+		saved = append(saved, f.Path)
+	}
+
+	if len(d.newFiles) == 0 {
+		return saved, nil
+	}
+
+	dataFiles := mappingValue(d.metadata.Content[0], "data_files")
+	if dataFiles == nil || len(dataFiles.Content) == 0 {
+		return saved, fmt.Errorf("metadata.yaml has no data_files")
+	}
+
+	// 在 data_files 最后一项之后按同样缩进插入新行，文件其余部分保持原样
+	last := dataFiles.Content[len(dataFiles.Content)-1]
+	prefix := strings.Repeat(" ", last.Column-3) + "- "
+	lines := bytes.SplitAfter(d.metadataRaw, []byte("\n"))
+	if last.Line > len(lines) || last.Column < 3 {
+		return saved, fmt.Errorf("unexpected data_files layout in metadata.yaml")
+	}
+	var added []byte
+	for _, path := range d.newFiles {
+		dataFiles.Content = append(dataFiles.Content, quotedString(path))
+		added = append(added, prefix+strconv.Quote(path)+"\n"...)
+	}
+	if !bytes.HasSuffix(lines[last.Line-1], []byte("\n")) {
+		lines[last.Line-1] = append(lines[last.Line-1], '\n')
+	}
+	out := bytes.Join(lines[:last.Line], nil)
+	out = append(out, added...)
+	out = append(out, bytes.Join(lines[last.Line:], nil)...)
+
+	metadataPath := filepath.Join(d.dataDir, "metadata.yaml")
+	if err := os.WriteFile(metadataPath, out, 0644); err != nil {
+		return saved, fmt.Errorf("failed to write metadata: %w", err)
+	}
+	d.metadataRaw = out
+	d.newFiles = nil
+
+	return append(saved, "metadata.yaml"), nil
+}
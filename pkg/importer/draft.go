@@ -0,0 +1,72 @@
+package importer
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/cmd4coder/cmd4coder/internal/model"
+	"gopkg.in/yaml.v3"
+)
+
+// Draft 导入生成的命令草稿，需人工审核后再加入数据文件
+type Draft struct {
+	Command *model.Command
+
+	// Missing 仍需人工补充的字段
+	Missing []string
+
+	// ValidationErr Validate 的校验结果，nil 表示已可直接加载
+	ValidationErr error
+}
+
+// NewDraft 校验命令并标记待补充字段
+func NewDraft(cmd *model.Command) *Draft {
+	d := &Draft{
+		Command:       cmd,
+		ValidationErr: cmd.Validate(),
+	}
+
+	if cmd.Category == "" {
+		d.Missing = append(d.Missing, "category")
+	}
+	if cmd.Description == "" {
+		d.Missing = append(d.Missing, "description")
+	}
+	if len(cmd.Usage) == 0 {
+		d.Missing = append(d.Missing, "usage")
+	}
+	if len(cmd.Examples) == 0 {
+		d.Missing = append(d.Missing, "examples")
+	}
+	if len(cmd.Platforms) == 0 {
+		d.Missing = append(d.Missing, "platforms")
+	}
+	// 风险说明无法从文档推断，始终需要人工确认
+	if len(cmd.Risks) == 0 {
+		d.Missing = append(d.Missing, "risks")
+	}
+
+	return d
+}
+
+// NeedsReview 是否仍有字段需要人工补充
+func (d *Draft) NeedsReview() bool {
+	return len(d.Missing) > 0 || d.ValidationErr != nil
+}
+
+// WriteYAML 以数据文件 commands 列表项的格式输出草稿，待补充字段以注释标出
+func (d *Draft) WriteYAML(w io.Writer) error {
+	if len(d.Missing) > 0 {
+		fmt.Fprintf(w, "# TODO(%s): %s\n", d.Command.Name, strings.Join(d.Missing, ", "))
+	}
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode([]*model.Command{d.Command}); err != nil {
+		return fmt.Errorf("failed to encode YAML: %w", err)
+	}
+
+	return encoder.Close()
+}
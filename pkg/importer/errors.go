@@ -0,0 +1,35 @@
+package importer
+
+import "fmt"
+
+// ErrNothingParsed 未能从输入中解析出命令
+type ErrNothingParsed struct {
+	Source string
+}
+
+func (e ErrNothingParsed) Error() string {
+	return fmt.Sprintf("no command could be parsed from %s", e.Source)
+}
+
+// ErrUnsupportedVersion 导出文件的格式版本不受支持
+type ErrUnsupportedVersion struct {
+	Version string
+}
+
+func (e ErrUnsupportedVersion) Error() string {
+	return fmt.Sprintf("unsupported export version %s", e.Version)
+}
+
+// ErrInvalidCommand 导入的命令未通过数据校验
+type ErrInvalidCommand struct {
+	Index int
+	Name  string
+	Err   error
+}
+
+func (e ErrInvalidCommand) Error() string {
+	if e.Name == "" {
+		return fmt.Sprintf("invalid command at index %d: %v", e.Index, e.Err)
+	}
+	return fmt.Sprintf("invalid command %s at index %d: %v", e.Name, e.Index, e.Err)
+}
@@ -0,0 +1,108 @@
+package importer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/cmd4coder/cmd4coder/internal/model"
+)
+
+// helpOptionPattern 匹配 "-a, --all    do not ignore entries" 形式的选项行
+var helpOptionPattern = regexp.MustCompile(`^(-\S.*?)(?:\s{2,}|\t+)(\S.*)$`)
+
+// ParseHelpText 解析 `--help` 输出文本，生成命令草稿；name 为空时从 Usage 行推断
+func ParseHelpText(name string, r io.Reader) (*model.Command, error) {
+	cmd := &model.Command{Name: name}
+
+	var lastOpt *model.Option
+	inUsage := false
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t")
+		trimmed := strings.TrimSpace(line)
+
+		if trimmed == "" {
+			inUsage = false
+			lastOpt = nil
+			continue
+		}
+
+		// Usage 行及其续行（"or: ..."）
+		if usage, ok := cutUsagePrefix(trimmed); ok {
+			inUsage = true
+			if usage != "" {
+				cmd.Usage = append(cmd.Usage, usage)
+			}
+			continue
+		}
+		if inUsage && line != trimmed {
+			if rest, ok := cutPrefixFold(trimmed, "or:"); ok {
+				trimmed = strings.TrimSpace(rest)
+			}
+			cmd.Usage = append(cmd.Usage, trimmed)
+			continue
+		}
+		inUsage = false
+
+		// 选项行
+		if strings.HasPrefix(trimmed, "-") {
+			opt := model.Option{Flag: trimmed}
+			if m := helpOptionPattern.FindStringSubmatch(trimmed); m != nil {
+				opt.Flag = strings.TrimSpace(m[1])
+				opt.Description = strings.TrimSpace(m[2])
+			}
+			cmd.Options = append(cmd.Options, opt)
+			lastOpt = &cmd.Options[len(cmd.Options)-1]
+			continue
+		}
+
+		// 选项描述独占下一行的情况
+		if lastOpt != nil && line != trimmed {
+			if lastOpt.Description == "" {
+				lastOpt.Description = trimmed
+			}
+			continue
+		}
+		lastOpt = nil
+
+		// 第一段非标题正文作为描述
+		if cmd.Description == "" && len(cmd.Options) == 0 && !strings.HasSuffix(trimmed, ":") {
+			cmd.Description = trimmed
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read help text: %w", err)
+	}
+
+	if cmd.Name == "" && len(cmd.Usage) > 0 {
+		cmd.Name = filepath.Base(strings.Fields(cmd.Usage[0])[0])
+	}
+	if cmd.Name == "" {
+		return nil, ErrNothingParsed{Source: "help text"}
+	}
+
+	return cmd, nil
+}
+
+// cutUsagePrefix 去除 "Usage:" 前缀（不区分大小写）
+func cutUsagePrefix(line string) (string, bool) {
+	rest, ok := cutPrefixFold(line, "usage:")
+	if !ok {
+		return "", false
+	}
+	return strings.TrimSpace(rest), true
+}
+
+// cutPrefixFold 不区分大小写地去除前缀
+func cutPrefixFold(s, prefix string) (string, bool) {
+	if len(s) < len(prefix) || !strings.EqualFold(s[:len(prefix)], prefix) {
+		return s, false
+	}
+	return s[len(prefix):], true
+}
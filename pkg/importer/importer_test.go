@@ -0,0 +1,198 @@
+package importer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+const lsManPage = `.\" Copyright notice
+.TH LS "1" "September 2024" "GNU coreutils 9.5" "User Commands"
+.SH NAME
+ls \- list directory contents
+.SH SYNOPSIS
+.B ls
+[\fI\,OPTION\/\fR]... [\fI\,FILE\/\fR]...
+.SH DESCRIPTION
+.PP
+List information about the FILEs (the current directory by default).
+.TP
+\fB\-a\fR, \fB\-\-all\fR
+do not ignore entries starting with .
+.TP
+\fB\-l\fR
+use a long listing format. Output is more verbose.
+.SH "SEE ALSO"
+dir(1), vdir(1)
+`
+
+func TestParseManPage(t *testing.T) {
+	cmd, err := ParseManPage(strings.NewReader(lsManPage))
+	if err != nil {
+		t.Fatalf("ParseManPage() error = %v", err)
+	}
+
+	if cmd.Name != "ls" {
+		t.Errorf("Name = %q, want ls", cmd.Name)
+	}
+	if cmd.Description != "list directory contents" {
+		t.Errorf("Description = %q", cmd.Description)
+	}
+	if len(cmd.Usage) != 1 || cmd.Usage[0] != "ls [OPTION]... [FILE]..." {
+		t.Errorf("Usage = %q", cmd.Usage)
+	}
+	if len(cmd.Options) != 2 {
+		t.Fatalf("expected 2 options, got %d: %+v", len(cmd.Options), cmd.Options)
+	}
+	if cmd.Options[0].Flag != "-a, --all" {
+		t.Errorf("Options[0].Flag = %q", cmd.Options[0].Flag)
+	}
+	if cmd.Options[1].Description != "use a long listing format." {
+		t.Errorf("Options[1].Description = %q", cmd.Options[1].Description)
+	}
+	if len(cmd.RelatedCommands) != 2 || cmd.RelatedCommands[0] != "dir" {
+		t.Errorf("RelatedCommands = %q", cmd.RelatedCommands)
+	}
+}
+
+func TestParseManPageMdoc(t *testing.T) {
+	src := `.Dd $Mdocdate$
+.Dt CAT 1
+.Sh NAME
+.Nm cat
+.Nd concatenate and print files
+.Sh SYNOPSIS
+.Nm cat
+.Op Fl benstuv
+.Op Ar
+.Sh DESCRIPTION
+.Bl -tag -width Ds
+.It Fl b
+Number the lines, but don't count blank lines.
+.It Fl n
+Number the output lines, starting at 1.
+.El
+`
+	cmd, err := ParseManPage(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("ParseManPage() error = %v", err)
+	}
+
+	if cmd.Name != "cat" || cmd.Description != "concatenate and print files" {
+		t.Errorf("got name %q description %q", cmd.Name, cmd.Description)
+	}
+	if len(cmd.Usage) != 1 || cmd.Usage[0] != "cat [-benstuv] [file ...]" {
+		t.Errorf("Usage = %q", cmd.Usage)
+	}
+	if len(cmd.Options) != 2 || cmd.Options[1].Flag != "-n" {
+		t.Errorf("Options = %+v", cmd.Options)
+	}
+}
+
+func TestParseManPageAdjacentTags(t *testing.T) {
+	src := `.TH IP 8
+.SH NAME
+ip \- show / manipulate routing, network devices
+.SH OPTIONS
+.IP -a
+.IP --addresses
+Show addresses for
+
+all interfaces. Repeat for more detail.
+.IP -s
+Output more information.
+.PP
+Unrelated paragraph.
+`
+	cmd, err := ParseManPage(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("ParseManPage() error = %v", err)
+	}
+
+	if len(cmd.Options) != 2 {
+		t.Fatalf("expected 2 options, got %+v", cmd.Options)
+	}
+	if cmd.Options[0].Flag != "-a, --addresses" || cmd.Options[0].Description != "Show addresses for all interfaces." {
+		t.Errorf("Options[0] = %+v", cmd.Options[0])
+	}
+	if cmd.Options[1].Flag != "-s" || cmd.Options[1].Description != "Output more information." {
+		t.Errorf("Options[1] = %+v", cmd.Options[1])
+	}
+}
+
+func TestParseManPageEmpty(t *testing.T) {
+	if _, err := ParseManPage(strings.NewReader(".\\\" nothing here\n")); err == nil {
+		t.Error("expected error for empty man page")
+	}
+}
+
+func TestParseHelpText(t *testing.T) {
+	help := `Usage: grep [OPTION]... PATTERNS [FILE]...
+  or:  grep [OPTION]... -e PATTERNS ... [FILE]...
+Search for PATTERNS in each FILE.
+
+Pattern selection and interpretation:
+  -E, --extended-regexp     PATTERNS are extended regular expressions
+  -i, --ignore-case         ignore case distinctions in patterns and data
+      --include=GLOB
+                            search only files that match GLOB
+`
+	cmd, err := ParseHelpText("", strings.NewReader(help))
+	if err != nil {
+		t.Fatalf("ParseHelpText() error = %v", err)
+	}
+
+	if cmd.Name != "grep" {
+		t.Errorf("Name = %q, want grep", cmd.Name)
+	}
+	if cmd.Description != "Search for PATTERNS in each FILE." {
+		t.Errorf("Description = %q", cmd.Description)
+	}
+	if len(cmd.Usage) != 2 || cmd.Usage[1] != "grep [OPTION]... -e PATTERNS ... [FILE]..." {
+		t.Errorf("Usage = %q", cmd.Usage)
+	}
+	if len(cmd.Options) != 3 {
+		t.Fatalf("expected 3 options, got %+v", cmd.Options)
+	}
+	if cmd.Options[0].Flag != "-E, --extended-regexp" {
+		t.Errorf("Options[0].Flag = %q", cmd.Options[0].Flag)
+	}
+	if cmd.Options[2].Description != "search only files that match GLOB" {
+		t.Errorf("Options[2].Description = %q", cmd.Options[2].Description)
+	}
+}
+
+func TestDraft(t *testing.T) {
+	cmd, err := ParseManPage(strings.NewReader(lsManPage))
+	if err != nil {
+		t.Fatalf("ParseManPage() error = %v", err)
+	}
+	cmd.Platforms = []string{"linux"}
+
+	draft := NewDraft(cmd)
+	if !draft.NeedsReview() {
+		t.Error("draft without examples should need review")
+	}
+	if draft.ValidationErr == nil {
+		t.Error("draft without category should fail validation")
+	}
+
+	want := map[string]bool{"category": true, "examples": true, "risks": true}
+	for _, field := range draft.Missing {
+		delete(want, field)
+	}
+	if len(want) > 0 {
+		t.Errorf("Missing = %v, expected to contain %v", draft.Missing, want)
+	}
+
+	var buf bytes.Buffer
+	if err := draft.WriteYAML(&buf); err != nil {
+		t.Fatalf("WriteYAML() error = %v", err)
+	}
+	out := buf.String()
+	for _, expected := range []string{"# TODO(ls):", "- name: ls", "flag: -a, --all"} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("YAML output does not contain %q:\n%s", expected, out)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package importer
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+
+	"github.com/cmd4coder/cmd4coder/internal/data"
+	"github.com/cmd4coder/cmd4coder/internal/model"
+	"github.com/cmd4coder/cmd4coder/pkg/export"
+	"gopkg.in/yaml.v3"
+)
+
+// jsonExport cmd4coder export -f json 生成的文档
+type jsonExport struct {
+	Version  string           `json:"version"`
+	Title    string           `json:"title,omitempty"`
+	Total    *int             `json:"total"`
+	Commands []*model.Command `json:"commands"`
+}
+
+// ParseJSONExport 解析 cmd4coder 导出的 JSON
+//
+// 支持带 version、total 的完整文档（-f json）和只有命令数组的紧凑格式（-f json-compact）。
+// 每个命令都需要通过数据校验，命令名称不能重复。
+func ParseJSONExport(r io.Reader) ([]*model.Command, error) {
+	br := bufio.NewReader(r)
+	first, err := firstNonSpace(br)
+	if err != nil {
+		return nil, ErrNothingParsed{Source: "JSON export"}
+	}
+
+	var commands []*model.Command
+	decoder := json.NewDecoder(br)
+	switch first {
+	case '[':
+		if err := decoder.Decode(&commands); err != nil {
+			return nil, fmt.Errorf("failed to parse JSON export: %w", err)
+		}
+	case '{':
+		var doc jsonExport
+		if err := decoder.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("failed to parse JSON export: %w", err)
+		}
+		if doc.Version != "" && !strings.HasPrefix(doc.Version, "1.") {
+			return nil, ErrUnsupportedVersion{Version: doc.Version}
+		}
+		if doc.Total != nil && *doc.Total != len(doc.Commands) {
+			return nil, fmt.Errorf("JSON export declares %d commands but contains %d", *doc.Total, len(doc.Commands))
+		}
+		commands = doc.Commands
+	default:
+		return nil, fmt.Errorf("failed to parse JSON export: unexpected %q", first)
+	}
+
+	if len(commands) == 0 {
+		return nil, ErrNothingParsed{Source: "JSON export"}
+	}
+
+	seen := make(map[string]bool, len(commands))
+	for i, cmd := range commands {
+		if cmd == nil {
+			return nil, ErrInvalidCommand{Index: i, Err: fmt.Errorf("null command")}
+		}
+		if err := cmd.Validate(); err != nil {
+			return nil, ErrInvalidCommand{Index: i, Name: cmd.Name, Err: err}
+		}
+		if seen[cmd.Name] {
+			return nil, model.ErrDuplicateCommand{Name: cmd.Name}
+		}
+		seen[cmd.Name] = true
+	}
+
+	return commands, nil
+}
+
+// firstNonSpace 读取第一个非空白字符后放回，用于区分文档和数组
+func firstNonSpace(br *bufio.Reader) (rune, error) {
+	for {
+		r, _, err := br.ReadRune()
+		if err != nil {
+			return 0, err
+		}
+		if r == '\ufeff' || unicode.IsSpace(r) {
+			continue
+		}
+		return r, br.UnreadRune()
+	}
+}
+
+// PlaceByCategory 按分类决定新命令写入的数据文件
+//
+// 已有该分类的数据文件时写入该文件，否则在 dir 下按分类名创建新文件并登记到 metadata.yaml。
+func (d *Dataset) PlaceByCategory(dir, description string) func(*model.Command) *DataFile {
+	return func(cmd *model.Command) *DataFile {
+		if f := d.FileForCategory(cmd.Category); f != nil {
+			return f
+		}
+		name := export.Slugify(cmd.Category)
+		if name == "" {
+			name = "imported"
+		}
+		return d.File(dir+"/"+name+".yaml", cmd.Category, description)
+	}
+}
+
+// Replace 用导入的命令替换数据集中的同名命令，不存在的命令由 place 决定写入位置
+//
+// 与 Merge 不同，导入内容中非空的字段会覆盖现有内容，适用于在其他工具中编辑导出文件后写回。
+// 写回后内容不变的命令（如用 --no-risks 导出的文件中省略了风险）不计入 Updated。
+func (d *Dataset) Replace(incoming []*model.Command, place func(*model.Command) *DataFile) (*MergeResult, error) {
+	result := &MergeResult{}
+
+	var rest []*model.Command
+	for _, cmd := range incoming {
+		existing, f := d.Lookup(cmd.Name)
+		if existing == nil {
+			rest = append(rest, cmd)
+			continue
+		}
+		put, changed, err := replacement(existing, cmd)
+		if err != nil {
+			return result, err
+		}
+		if !changed {
+			continue
+		}
+		if err := d.Put(f, put); err != nil {
+			return result, err
+		}
+		result.Updated = append(result.Updated, cmd)
+	}
+
+	added, err := d.Merge(rest, place)
+	if added != nil {
+		result.Added = added.Added
+		result.Unmatched = added.Unmatched
+	}
+	return result, err
+}
+
+// replacement 计算覆盖写入的命令以及写回后内容是否变化
+//
+// 导出的命令经过加载时的规范化（平台覆盖的键名统一为 darwin 等），数据文件中的原始内容则没有，
+// 因此两边都规范化后再比较。平台覆盖规范化后相同时保留数据文件中的写法，避免无意义地改写键名。
+func replacement(existing, incoming *model.Command) (*model.Command, bool, error) {
+	merged, err := overlayCommand(existing, incoming)
+	if err != nil {
+		return nil, false, err
+	}
+
+	before, after := *existing, *merged
+	if err := data.NormalizePlatformOverrides(&before); err != nil {
+		return nil, false, err
+	}
+	if err := data.NormalizePlatformOverrides(&after); err != nil {
+		return nil, false, err
+	}
+	if sameCommand(&before, &after) {
+		return nil, false, nil
+	}
+
+	put := *incoming
+	if sameCommand(&model.Command{PlatformOverrides: before.PlatformOverrides}, &model.Command{PlatformOverrides: after.PlatformOverrides}) {
+		put.PlatformOverrides = existing.PlatformOverrides
+	}
+	return &put, true, nil
+}
+
+// overlayCommand 按 PutCommand 的规则把导入命令的非零字段覆盖到已有命令上，返回覆盖后的副本
+func overlayCommand(existing, incoming *model.Command) (*model.Command, error) {
+	var base, top yaml.Node
+	if err := base.Encode(existing); err != nil {
+		return nil, fmt.Errorf("failed to encode command %s: %w", existing.Name, err)
+	}
+	if err := top.Encode(incoming); err != nil {
+		return nil, fmt.Errorf("failed to encode command %s: %w", incoming.Name, err)
+	}
+	dropZeroValues(&top)
+	for i := 0; i+1 < len(top.Content); i += 2 {
+		setMappingValue(&base, top.Content[i].Value, top.Content[i+1])
+	}
+
+	var merged model.Command
+	if err := base.Decode(&merged); err != nil {
+		return nil, fmt.Errorf("failed to decode command %s: %w", existing.Name, err)
+	}
+	return &merged, nil
+}
+
+// sameCommand 比较两个命令编码为 YAML 后是否一致
+func sameCommand(a, b *model.Command) bool {
+	var na, nb yaml.Node
+	if na.Encode(a) != nil || nb.Encode(b) != nil {
+		return false
+	}
+	return nodesEqual(&na, &nb)
+}
@@ -0,0 +1,185 @@
+package importer
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cmd4coder/cmd4coder/internal/model"
+)
+
+const jsonDocument = `{
+  "version": "1.0",
+  "total": 2,
+  "commands": [
+    {
+      "name": "tar",
+      "category": "操作系统/通用Linux命令",
+      "description": "打包归档工具（已编辑）",
+      "platforms": ["linux"],
+      "usage": ["tar [options] <file>"],
+      "examples": [{"command": "tar cf a.tar a", "description": "创建归档"}]
+    },
+    {
+      "name": "jq",
+      "category": "开发工具/JSON处理",
+      "description": "命令行 JSON 处理器",
+      "platforms": ["linux", "darwin"],
+      "usage": ["jq <filter> [file]"],
+      "examples": [{"command": "jq . a.json", "description": "格式化输出"}]
+    }
+  ]
+}`
+
+func TestParseJSONExport(t *testing.T) {
+	commands, err := ParseJSONExport(strings.NewReader("\ufeff\n" + jsonDocument))
+	if err != nil {
+		t.Fatalf("ParseJSONExport() error = %v", err)
+	}
+	if len(commands) != 2 || commands[1].Name != "jq" {
+		t.Fatalf("commands = %+v", commands)
+	}
+
+	compact := `[{"name":"jq","category":"开发工具/JSON处理","description":"JSON 处理器","platforms":["linux"],"usage":["jq ."],"examples":[{"command":"jq ."}]}]`
+	commands, err = ParseJSONExport(strings.NewReader(compact))
+	if err != nil {
+		t.Fatalf("ParseJSONExport() compact error = %v", err)
+	}
+	if len(commands) != 1 || commands[0].Platforms[0] != "linux" {
+		t.Errorf("compact commands = %+v", commands)
+	}
+}
+
+func TestParseJSONExportErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		check func(error) bool
+	}{
+		{"empty", "  ", func(err error) bool { return errors.As(err, &ErrNothingParsed{}) }},
+		{"no commands", `{"version":"1.0","total":0,"commands":[]}`, func(err error) bool { return errors.As(err, &ErrNothingParsed{}) }},
+		{"not json", "name: tar", func(err error) bool { return err != nil }},
+		{"version", `{"version":"2.0","commands":[]}`, func(err error) bool { return errors.As(err, &ErrUnsupportedVersion{}) }},
+		{"total", strings.Replace(jsonDocument, `"total": 2`, `"total": 3`, 1), func(err error) bool { return err != nil }},
+		{"invalid", `[{"name":"tar","category":"a","description":"b"}]`, func(err error) bool {
+			var target ErrInvalidCommand
+			return errors.As(err, &target) && target.Name == "tar"
+		}},
+		{"duplicate", strings.Replace(jsonDocument, `"name": "jq"`, `"name": "tar"`, 1), func(err error) bool {
+			return errors.As(err, &model.ErrDuplicateCommand{})
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseJSONExport(strings.NewReader(tt.input))
+			if !tt.check(err) {
+				t.Errorf("ParseJSONExport() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestDatasetReplace(t *testing.T) {
+	dataDir := t.TempDir()
+	writeFile(t, filepath.Join(dataDir, "metadata.yaml"), `version: "1.0.0"
+categories:
+  os_common:
+    id: os_common
+    name: "操作系统/通用Linux命令"
+    order: 1
+data_files:
+  - "os/common.yaml"
+`)
+	writeFile(t, filepath.Join(dataDir, "os", "common.yaml"), `category: "操作系统/通用Linux命令"
+description: "通用命令"
+commands:
+  - name: "tar"
+    category: "操作系统/通用Linux命令"
+    description: "打包归档工具"
+    platforms:
+      - "linux"
+    usage:
+      - "tar [options] <file>"
+    examples:
+      - command: "tar cf a.tar a"
+        description: "创建归档"
+`)
+
+	dataset, err := LoadDataset(dataDir)
+	if err != nil {
+		t.Fatalf("LoadDataset() error = %v", err)
+	}
+	incoming, err := ParseJSONExport(strings.NewReader(jsonDocument))
+	if err != nil {
+		t.Fatalf("ParseJSONExport() error = %v", err)
+	}
+
+	result, err := dataset.Replace(incoming, dataset.PlaceByCategory("imported", "导入"))
+	if err != nil {
+		t.Fatalf("Replace() error = %v", err)
+	}
+	if len(result.Updated) != 1 || len(result.Added) != 1 {
+		t.Fatalf("result = %+v", result)
+	}
+	if _, err := dataset.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	reloaded, err := LoadDataset(dataDir)
+	if err != nil {
+		t.Fatalf("LoadDataset() after save error = %v", err)
+	}
+	if cmd, _ := reloaded.Lookup("tar"); cmd == nil || cmd.Description != "打包归档工具（已编辑）" {
+		t.Errorf("tar was not replaced: %+v", cmd)
+	}
+	if _, err := os.Stat(filepath.Join(dataDir, "imported", "开发工具-json处理.yaml")); err != nil {
+		t.Errorf("new category file was not created: %v", err)
+	}
+	if cmd, _ := reloaded.Lookup("jq"); cmd == nil || cmd.Category != "开发工具/JSON处理" {
+		t.Errorf("jq was not saved: %+v", cmd)
+	}
+
+	// 再次导入相同内容不产生更新
+	result, err = reloaded.Replace(incoming, reloaded.PlaceByCategory("imported", "导入"))
+	if err != nil {
+		t.Fatalf("Replace() again error = %v", err)
+	}
+	if len(result.Updated) != 0 || len(result.Added) != 0 {
+		t.Errorf("second import result = %+v", result)
+	}
+}
+
+func TestReplacementNormalized(t *testing.T) {
+	existing := &model.Command{
+		Name:      "date",
+		Platforms: []string{"linux", "macos"},
+		Usage:     []string{"date"},
+		Risks:     []model.Risk{{Level: model.RiskLevelLow, Description: "只读"}},
+		PlatformOverrides: map[string]model.PlatformOverride{
+			"macos": {Usage: []string{"date -j"}},
+		},
+	}
+
+	// 导出时平台覆盖的键名已规范化，--no-risks 导出时没有风险
+	exported := *existing
+	exported.Risks = nil
+	exported.PlatformOverrides = map[string]model.PlatformOverride{
+		"darwin": {Usage: []string{"date -j"}},
+	}
+	if _, changed, err := replacement(existing, &exported); err != nil || changed {
+		t.Errorf("replacement() changed = %v, err = %v, expected no change", changed, err)
+	}
+
+	edited := exported
+	edited.Usage = []string{"date [+format]"}
+	put, changed, err := replacement(existing, &edited)
+	if err != nil || !changed {
+		t.Fatalf("replacement() changed = %v, err = %v, expected change", changed, err)
+	}
+	if _, ok := put.PlatformOverrides["macos"]; !ok {
+		t.Errorf("platform override keys should be kept as written: %v", put.PlatformOverrides)
+	}
+}
@@ -0,0 +1,443 @@
+package importer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/cmd4coder/cmd4coder/internal/model"
+)
+
+// ParseManPage 解析man手册页源码（groff man 或 mandoc mdoc 宏），生成命令草稿
+func ParseManPage(r io.Reader) (*model.Command, error) {
+	p := &manParser{cmd: &model.Command{}}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		p.parseLine(scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read man page: %w", err)
+	}
+	p.flush()
+	p.finishOption()
+
+	if p.cmd.Name == "" {
+		p.cmd.Name = p.title
+	}
+	if p.cmd.Name == "" {
+		return nil, ErrNothingParsed{Source: "man page"}
+	}
+
+	return p.cmd, nil
+}
+
+// manParser man手册页解析状态
+type manParser struct {
+	cmd *model.Command
+
+	title    string   // .TH/.Dt 中的标题
+	section  string   // 当前所在章节（大写）
+	buf      []string // 当前段落文本
+	tag      string   // 当前 .TP/.IP/.It 标签，相邻的多个标签以 ", " 连接
+	awaitTag bool     // .TP 后下一行为标签
+
+	optFlag string // 正在收集说明的选项
+	optText string // 选项说明，空行后的段落继续追加
+}
+
+// parseLine 处理单行源码
+func (p *manParser) parseLine(raw string) {
+	if isRoffComment(raw) {
+		return
+	}
+
+	if strings.TrimSpace(raw) == "" {
+		p.flush()
+		return
+	}
+
+	if strings.HasPrefix(raw, ".") || strings.HasPrefix(raw, "'") {
+		name, args := splitMacro(raw[1:])
+		p.macro(name, args)
+		return
+	}
+
+	p.appendText(unescapeRoff(raw))
+}
+
+// macro 处理宏指令
+func (p *manParser) macro(name string, args []string) {
+	switch name {
+	case "TH", "Dt":
+		if len(args) > 0 {
+			p.title = strings.ToLower(unescapeRoff(args[0]))
+		}
+
+	case "SH", "Sh":
+		p.flush()
+		p.finishOption()
+		p.tag = ""
+		p.section = strings.ToUpper(unescapeRoff(strings.Join(args, " ")))
+
+	case "SS", "Ss", "PP", "P", "LP", "El":
+		// 缩进段落或列表结束，选项说明随之结束
+		p.flush()
+		p.finishOption()
+
+	case "Pp", "br", "sp", "Bl", "RS", "RE":
+		p.flush()
+
+	case "TP":
+		p.flush()
+		p.awaitTag = true
+
+	case "IP":
+		p.flush()
+		if len(args) > 0 {
+			p.setTag(unescapeRoff(args[0]))
+		}
+
+	case "It":
+		p.flush()
+		p.setTag(mdocText(args, p.cmd.Name))
+
+	case "B", "I", "SM", "SB":
+		p.appendText(unescapeRoff(strings.Join(args, " ")))
+
+	case "BR", "RB", "BI", "IB", "IR", "RI":
+		// 交替字体宏，参数之间不加空格
+		p.appendText(unescapeRoff(strings.Join(args, "")))
+
+	case "Nm":
+		p.mdocName(args)
+
+	case "Nd":
+		p.cmd.Description = mdocText(args, p.cmd.Name)
+
+	default:
+		if isMdocMacro(name) {
+			p.appendText(mdocText(append([]string{name}, args...), p.cmd.Name))
+		}
+	}
+}
+
+// mdocName 处理 mdoc 的 .Nm 宏
+func (p *manParser) mdocName(args []string) {
+	if p.section == "NAME" {
+		if p.cmd.Name == "" && len(args) > 0 {
+			p.cmd.Name = strings.TrimSuffix(args[0], ",")
+		}
+		return
+	}
+
+	// 概要中每个 .Nm 开始一种新的用法
+	if p.section == "SYNOPSIS" {
+		p.flush()
+	}
+	p.appendText(mdocText(append([]string{"Nm"}, args...), p.cmd.Name))
+}
+
+// appendText 追加正文文本
+func (p *manParser) appendText(text string) {
+	if p.awaitTag {
+		p.setTag(text)
+		p.awaitTag = false
+		return
+	}
+	p.buf = append(p.buf, text)
+}
+
+// setTag 设置段落标签；上一个选项标签之后还没有正文时（如 .IP -a 紧接 .IP --addresses），
+// 两个标签是同一选项的不同写法，合并为 "-a, --addresses"
+func (p *manParser) setTag(tag string) {
+	if strings.HasPrefix(p.tag, "-") && strings.HasPrefix(tag, "-") {
+		p.tag += ", " + tag
+		return
+	}
+	p.tag = tag
+}
+
+// finishOption 结束正在收集的选项，说明取第一句
+func (p *manParser) finishOption() {
+	if p.optFlag != "" && p.optText != "" {
+		p.cmd.Options = append(p.cmd.Options, model.Option{
+			Flag:        p.optFlag,
+			Description: firstSentence(p.optText),
+		})
+	}
+	p.optFlag, p.optText = "", ""
+}
+
+// flush 结束当前段落，按章节归档
+func (p *manParser) flush() {
+	text := strings.Join(strings.Fields(strings.Join(p.buf, " ")), " ")
+	p.buf = nil
+
+	switch p.section {
+	case "NAME":
+		p.parseNameLine(text)
+
+	case "SYNOPSIS":
+		if text != "" {
+			p.cmd.Usage = append(p.cmd.Usage, text)
+		}
+
+	case "SEE ALSO":
+		p.cmd.RelatedCommands = append(p.cmd.RelatedCommands, parseSeeAlso(text)...)
+
+	default:
+		switch {
+		case p.tag != "" && text != "":
+			p.finishOption()
+			if strings.HasPrefix(p.tag, "-") {
+				p.optFlag, p.optText = p.tag, text
+			}
+		case p.optFlag != "" && text != "":
+			// 选项说明在空行之后继续
+			p.optText += " " + text
+		}
+	}
+
+	if text != "" {
+		p.tag = ""
+	}
+}
+
+// parseNameLine 解析 NAME 章节，如 "ls - list directory contents"
+func (p *manParser) parseNameLine(text string) {
+	if text == "" {
+		return
+	}
+
+	names, desc, found := strings.Cut(text, " - ")
+	if !found {
+		if p.cmd.Name == "" {
+			p.cmd.Name = strings.TrimSuffix(strings.Fields(text)[0], ",")
+		}
+		return
+	}
+
+	if p.cmd.Name == "" {
+		p.cmd.Name = strings.TrimSpace(strings.Split(names, ",")[0])
+	}
+	if p.cmd.Description == "" {
+		p.cmd.Description = strings.TrimSpace(desc)
+	}
+}
+
+// parseSeeAlso 从 SEE ALSO 章节提取相关命令，如 "dir(1), vdir(1)"
+func parseSeeAlso(text string) []string {
+	var related []string
+	for _, ref := range strings.Split(text, ",") {
+		ref = strings.TrimSpace(ref)
+		if i := strings.Index(ref, "("); i > 0 {
+			ref = ref[:i]
+		}
+		if ref != "" && !strings.Contains(ref, " ") {
+			related = append(related, ref)
+		}
+	}
+	return related
+}
+
+// isRoffComment 判断是否为 roff 注释行
+func isRoffComment(line string) bool {
+	return strings.HasPrefix(line, `.\"`) || strings.HasPrefix(line, `'\"`) ||
+		strings.HasPrefix(line, `.\#`) || strings.HasPrefix(line, `\#`)
+}
+
+// splitMacro 拆分宏名和参数，支持双引号包裹的参数
+func splitMacro(line string) (string, []string) {
+	var fields []string
+	var cur strings.Builder
+	inQuote := false
+	hasToken := false
+
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case c == '"':
+			inQuote = !inQuote
+			hasToken = true
+		case (c == ' ' || c == '\t') && !inQuote:
+			if hasToken {
+				fields = append(fields, cur.String())
+				cur.Reset()
+				hasToken = false
+			}
+		default:
+			cur.WriteByte(c)
+			hasToken = true
+		}
+	}
+	if hasToken {
+		fields = append(fields, cur.String())
+	}
+
+	if len(fields) == 0 {
+		return "", nil
+	}
+	return fields[0], fields[1:]
+}
+
+// roffSpecialChars roff 特殊字符转义
+var roffSpecialChars = map[string]string{
+	"em": "—", "en": "–", "aq": "'", "dq": `"`, "lq": `"`, "rq": `"`,
+	"oq": "'", "cq": "'", "bu": "•", "co": "©", "rg": "®", "hy": "-",
+	"mi": "-", "ti": "~", "ha": "^", "lB": "[", "rB": "]", "ba": "|",
+}
+
+// unescapeRoff 去除 roff 字体切换和转义序列
+func unescapeRoff(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 >= len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+
+		i++
+		switch s[i] {
+		case 'f':
+			// \fB \fI \fR \fP \f(CW \f[B]
+			i = skipRoffArg(s, i+1) - 1
+		case 's':
+			// \s-1 \s+1 \s0
+			if i+1 < len(s) && (s[i+1] == '-' || s[i+1] == '+') {
+				i++
+			}
+			if i+1 < len(s) && s[i+1] >= '0' && s[i+1] <= '9' {
+				i++
+			}
+		case '(':
+			if i+2 < len(s) {
+				b.WriteString(roffSpecialChars[s[i+1:i+3]])
+				i += 2
+			}
+		case '*':
+			// \*(lq \*R 字符串变量
+			end := skipRoffArg(s, i+1)
+			name := strings.Trim(s[i+1:end], "([]")
+			b.WriteString(roffSpecialChars[name])
+			i = end - 1
+		case '[':
+			end := strings.IndexByte(s[i:], ']')
+			if end < 0 {
+				i = len(s)
+				break
+			}
+			b.WriteString(roffSpecialChars[s[i+1:i+end]])
+			i += end
+		case '-':
+			b.WriteByte('-')
+		case 'e', '\\':
+			b.WriteByte('\\')
+		case ' ', '~':
+			b.WriteByte(' ')
+		case '&', '|', '^', 'c', ':', '%', ',', '/':
+			// 零宽字符，忽略
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
+
+// skipRoffArg 跳过转义序列的参数（单字符、(xx 或 [xxx]），返回参数之后的位置
+func skipRoffArg(s string, i int) int {
+	if i >= len(s) {
+		return i
+	}
+	switch s[i] {
+	case '(':
+		if i+3 <= len(s) {
+			return i + 3
+		}
+		return len(s)
+	case '[':
+		if end := strings.IndexByte(s[i:], ']'); end >= 0 {
+			return i + end + 1
+		}
+		return len(s)
+	default:
+		return i + 1
+	}
+}
+
+// mdocMacros 行内出现的 mdoc 宏
+var mdocMacros = map[string]bool{
+	"Fl": true, "Ar": true, "Op": true, "Oo": true, "Oc": true, "Cm": true,
+	"Ic": true, "Pa": true, "Li": true, "Em": true, "Sy": true, "Ev": true,
+	"Va": true, "Dv": true, "Ql": true, "Nm": true, "Xr": true, "Ns": true,
+	"Pq": true, "Dq": true, "Sq": true, "Ek": true, "Bk": true,
+}
+
+// isMdocMacro 判断是否为 mdoc 行内宏
+func isMdocMacro(name string) bool {
+	return mdocMacros[name]
+}
+
+// mdocText 将 mdoc 宏参数渲染为纯文本，如 "Op Fl a Ar file" -> "[-a file]"
+func mdocText(args []string, name string) string {
+	var parts []string
+	for i := 0; i < len(args); i++ {
+		next := ""
+		if i+1 < len(args) && !isMdocMacro(args[i+1]) {
+			next = args[i+1]
+		}
+
+		switch args[i] {
+		case "Op":
+			parts = append(parts, "["+mdocText(args[i+1:], name)+"]")
+			return strings.Join(parts, " ")
+		case "Oo":
+			parts = append(parts, "[")
+		case "Oc":
+			parts = append(parts, "]")
+		case "Fl":
+			parts = append(parts, "-"+next)
+			if next != "" {
+				i++
+			}
+		case "Nm":
+			if next != "" {
+				parts = append(parts, next)
+				i++
+			} else {
+				parts = append(parts, name)
+			}
+		case "Ar":
+			if next == "" {
+				next = "file ..."
+			} else {
+				i++
+			}
+			parts = append(parts, next)
+		case "Xr":
+			if i+2 < len(args) {
+				parts = append(parts, args[i+1]+"("+args[i+2]+")")
+				i += 2
+			}
+		default:
+			if !isMdocMacro(args[i]) {
+				parts = append(parts, unescapeRoff(args[i]))
+			}
+		}
+	}
+
+	text := strings.Join(parts, " ")
+	text = strings.ReplaceAll(text, "[ ", "[")
+	text = strings.ReplaceAll(text, " ]", "]")
+	return text
+}
+
+// firstSentence 截取第一句话作为简述
+func firstSentence(text string) string {
+	if i := strings.Index(text, ". "); i > 0 {
+		return text[:i+1]
+	}
+	return text
+}
@@ -0,0 +1,197 @@
+package importer
+
+import (
+	"strings"
+
+	"github.com/cmd4coder/cmd4coder/internal/model"
+	"github.com/cmd4coder/cmd4coder/pkg/platform"
+)
+
+// Conflict 合并冲突：已有的人工维护内容与导入内容不一致，保留原值并记录
+type Conflict struct {
+	Command  string
+	Field    string
+	Existing string
+	Incoming string
+}
+
+// MergeResult 合并结果
+type MergeResult struct {
+	Added     []*model.Command // 新增的命令
+	Updated   []*model.Command // 补充了内容的已有命令
+	Unmatched []*model.Command // 数据集中不存在且未指定写入位置的命令
+	Conflicts []Conflict
+}
+
+// MergeCommand 把导入的命令合并到已有命令
+//
+// 只补充空字段并追加新的选项、示例和链接，不覆盖任何人工维护的内容；
+// 返回是否有变化以及与已有内容不一致的字段：分类不同、导入的平台超出已有平台、
+// 同一说明的示例对应不同命令行（此时不追加该示例）。描述等自由文本从不覆盖，
+// 不同来源的措辞和语言本就不同，不算冲突。
+func MergeCommand(existing, incoming *model.Command) (bool, []Conflict) {
+	changed := false
+	var conflicts []Conflict
+
+	conflict := func(field, oldValue, newValue string) {
+		conflicts = append(conflicts, Conflict{
+			Command:  existing.Name,
+			Field:    field,
+			Existing: oldValue,
+			Incoming: newValue,
+		})
+	}
+
+	if existing.Description == "" && incoming.Description != "" {
+		existing.Description = incoming.Description
+		changed = true
+	}
+
+	if existing.Category != "" && incoming.Category != "" && existing.Category != incoming.Category {
+		conflict("category", existing.Category, incoming.Category)
+	}
+
+	if len(existing.Usage) == 0 && len(incoming.Usage) > 0 {
+		existing.Usage = incoming.Usage
+		changed = true
+	}
+	if len(existing.Platforms) == 0 && len(incoming.Platforms) > 0 {
+		existing.Platforms = incoming.Platforms
+		changed = true
+	} else {
+		for _, p := range incoming.Platforms {
+			if !platform.Covers(existing.Platforms, p) {
+				conflict("platforms", strings.Join(existing.Platforms, ", "), strings.Join(incoming.Platforms, ", "))
+				break
+			}
+		}
+	}
+
+	for _, opt := range incoming.Options {
+		found := false
+		for _, old := range existing.Options {
+			if old.Flag == opt.Flag {
+				found = true
+				break
+			}
+		}
+		if !found {
+			existing.Options = append(existing.Options, opt)
+			changed = true
+		}
+	}
+
+	for _, ex := range incoming.Examples {
+		found := false
+		for _, old := range existing.Examples {
+			if normalizeCommandLine(old.Command) == normalizeCommandLine(ex.Command) {
+				found = true
+				break
+			}
+		}
+		if found {
+			continue
+		}
+
+		if old := exampleWithDescription(existing.Examples, ex.Description); old != nil {
+			conflict("examples", old.Command, ex.Command)
+			continue
+		}
+		existing.Examples = append(existing.Examples, ex)
+		changed = true
+	}
+
+	if added := appendMissing(&existing.Notes, incoming.Notes); added {
+		changed = true
+	}
+	if added := appendMissing(&existing.RelatedCommands, incoming.RelatedCommands); added {
+		changed = true
+	}
+	if added := appendMissing(&existing.References, incoming.References); added {
+		changed = true
+	}
+
+	return changed, conflicts
+}
+
+// exampleWithDescription 查找说明相同（忽略大小写和首尾空白）的示例，说明为空时不匹配
+func exampleWithDescription(examples []model.Example, description string) *model.Example {
+	description = strings.TrimSpace(description)
+	if description == "" {
+		return nil
+	}
+	for i := range examples {
+		if strings.EqualFold(strings.TrimSpace(examples[i].Description), description) {
+			return &examples[i]
+		}
+	}
+	return nil
+}
+
+// appendMissing 追加目标中尚不存在的字符串
+func appendMissing(dst *[]string, values []string) bool {
+	added := false
+	for _, v := range values {
+		found := false
+		for _, old := range *dst {
+			if old == v {
+				found = true
+				break
+			}
+		}
+		if !found {
+			*dst = append(*dst, v)
+			added = true
+		}
+	}
+	return added
+}
+
+// normalizeCommandLine 规范化命令行用于比较：合并空白并去掉 sudo 前缀
+func normalizeCommandLine(line string) string {
+	line = strings.Join(strings.Fields(line), " ")
+	return strings.TrimPrefix(line, "sudo ")
+}
+
+// Merge 把导入命令合并进数据集
+//
+// 同名命令调用 MergeCommand 补充内容；不存在的命令由 place 决定写入哪个数据文件，
+// place 为 nil 或返回 nil 时只记录在 Unmatched 中。
+func (d *Dataset) Merge(incoming []*model.Command, place func(*model.Command) *DataFile) (*MergeResult, error) {
+	result := &MergeResult{}
+
+	for _, cmd := range incoming {
+		existing, f := d.Lookup(cmd.Name)
+		if existing != nil {
+			changed, conflicts := MergeCommand(existing, cmd)
+			result.Conflicts = append(result.Conflicts, conflicts...)
+			if !changed {
+				continue
+			}
+			if err := d.Put(f, existing); err != nil {
+				return result, err
+			}
+			result.Updated = append(result.Updated, existing)
+			continue
+		}
+
+		var target *DataFile
+		if place != nil {
+			target = place(cmd)
+		}
+		if target == nil {
+			result.Unmatched = append(result.Unmatched, cmd)
+			continue
+		}
+
+		if cmd.Category == "" {
+			cmd.Category = target.Category()
+		}
+		if err := d.Put(target, cmd); err != nil {
+			return result, err
+		}
+		result.Added = append(result.Added, cmd)
+	}
+
+	return result, nil
+}
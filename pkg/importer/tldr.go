@@ -0,0 +1,182 @@
+package importer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/cmd4coder/cmd4coder/internal/model"
+)
+
+// tldrPlatforms tldr-pages 平台目录到数据集平台名称的映射
+var tldrPlatforms = map[string][]string{
+	"common":  {"linux", "darwin"},
+	"linux":   {"linux"},
+	"osx":     {"darwin"},
+	"windows": {"windows"},
+	"freebsd": {"unix"},
+	"openbsd": {"unix"},
+	"netbsd":  {"unix"},
+	"sunos":   {"unix"},
+}
+
+// tldrPlaceholder 匹配 {{placeholder}} 参数
+var tldrPlaceholder = regexp.MustCompile(`\{\{(.*?)\}\}`)
+
+// tldrLink 匹配 <https://...> 形式的链接
+var tldrLink = regexp.MustCompile(`<(https?://[^>]+)>`)
+
+// tldrCode 匹配 `code` 形式的行内代码
+var tldrCode = regexp.MustCompile("`([^`]+)`")
+
+// ParseTLDRPage 解析单个 tldr 页面
+func ParseTLDRPage(r io.Reader) (*model.Command, error) {
+	cmd := &model.Command{}
+	var pendingDesc string
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case strings.HasPrefix(line, "# "):
+			cmd.Name = strings.TrimSpace(line[2:])
+
+		case strings.HasPrefix(line, ">"):
+			parseTLDRSummary(cmd, strings.TrimSpace(line[1:]))
+
+		case strings.HasPrefix(line, "- "):
+			pendingDesc = strings.TrimSuffix(strings.TrimSpace(line[2:]), ":")
+
+		case strings.HasPrefix(line, "`") && strings.HasSuffix(line, "`") && len(line) > 1:
+			cmd.Examples = append(cmd.Examples, model.Example{
+				Command:     convertTLDRPlaceholders(strings.Trim(line, "`")),
+				Description: pendingDesc,
+			})
+			pendingDesc = ""
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read tldr page: %w", err)
+	}
+
+	if cmd.Name == "" {
+		return nil, ErrNothingParsed{Source: "tldr page"}
+	}
+	if len(cmd.Examples) > 0 {
+		cmd.Usage = []string{cmd.Examples[0].Command}
+	}
+
+	return cmd, nil
+}
+
+// parseTLDRSummary 解析页面开头的 "> ..." 摘要行
+func parseTLDRSummary(cmd *model.Command, text string) {
+	switch {
+	case strings.HasPrefix(text, "More information:"):
+		for _, m := range tldrLink.FindAllStringSubmatch(text, -1) {
+			cmd.References = append(cmd.References, m[1])
+		}
+
+	case strings.HasPrefix(text, "See also:"):
+		for _, m := range tldrCode.FindAllStringSubmatch(text, -1) {
+			cmd.RelatedCommands = append(cmd.RelatedCommands, m[1])
+		}
+
+	case cmd.Description == "":
+		cmd.Description = text
+
+	default:
+		cmd.Notes = append(cmd.Notes, text)
+	}
+}
+
+// convertTLDRPlaceholders 把 {{path/to/file}} 转为数据集使用的 <path/to/file> 形式，
+// 选项占位符 {{[-r|--recursive]}} 取第一个写法
+func convertTLDRPlaceholders(command string) string {
+	return tldrPlaceholder.ReplaceAllStringFunc(command, func(m string) string {
+		inner := m[2 : len(m)-2]
+		if strings.HasPrefix(inner, "[") && strings.HasSuffix(inner, "]") && strings.Contains(inner, "|") {
+			return strings.Split(inner[1:len(inner)-1], "|")[0]
+		}
+		return "<" + inner + ">"
+	})
+}
+
+// LoadTLDRPages 读取本地 tldr-pages 检出目录
+//
+// root 可以是仓库根目录或 pages 目录本身；lang 为空时读取英文页面，否则读取 pages.<lang>。
+// 同一命令出现在多个平台目录时合并平台列表，内容以先读到的为准（common 优先）。
+func LoadTLDRPages(root, lang string) ([]*model.Command, error) {
+	pagesDir := filepath.Join(root, "pages")
+	if lang != "" {
+		pagesDir += "." + lang
+	}
+	if _, err := os.Stat(pagesDir); os.IsNotExist(err) {
+		pagesDir = root
+	}
+
+	platformDirs := make([]string, 0, len(tldrPlatforms))
+	for dir := range tldrPlatforms {
+		platformDirs = append(platformDirs, dir)
+	}
+	sort.Slice(platformDirs, func(i, j int) bool {
+		if platformDirs[i] == "common" || platformDirs[j] == "common" {
+			return platformDirs[i] == "common"
+		}
+		return platformDirs[i] < platformDirs[j]
+	})
+
+	byName := make(map[string]*model.Command)
+	var commands []*model.Command
+
+	for _, dir := range platformDirs {
+		files, err := filepath.Glob(filepath.Join(pagesDir, dir, "*.md"))
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(files)
+
+		for _, file := range files {
+			cmd, err := loadTLDRFile(file)
+			if err != nil {
+				return nil, err
+			}
+
+			if existing, ok := byName[cmd.Name]; ok {
+				appendMissing(&existing.Platforms, tldrPlatforms[dir])
+				continue
+			}
+
+			cmd.Platforms = append([]string(nil), tldrPlatforms[dir]...)
+			byName[cmd.Name] = cmd
+			commands = append(commands, cmd)
+		}
+	}
+
+	if len(commands) == 0 {
+		return nil, ErrNothingParsed{Source: pagesDir}
+	}
+
+	return commands, nil
+}
+
+// loadTLDRFile 解析单个页面文件
+func loadTLDRFile(path string) (*model.Command, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, model.ErrDataLoadFailed{File: path, Err: err}
+	}
+	defer f.Close()
+
+	cmd, err := ParseTLDRPage(f)
+	if err != nil {
+		return nil, model.ErrDataLoadFailed{File: path, Err: err}
+	}
+	return cmd, nil
+}
@@ -0,0 +1,274 @@
+package importer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cmd4coder/cmd4coder/internal/model"
+)
+
+const tarPage = `# tar
+
+> Archiving utility.
+> Often combined with a compression method, such as gzip or bzip2.
+> See also: ` + "`7z`, `zip`" + `.
+> More information: <https://www.gnu.org/software/tar>.
+
+- [c]reate an archive and write it to a [f]ile:
+
+` + "`tar cf {{path/to/target.tar}} {{path/to/file1 path/to/file2 ...}}`" + `
+
+- E[x]tract a (compressed) archive [f]ile into the current directory [v]erbosely:
+
+` + "`tar {{[-x|--extract]}}vf {{path/to/source.tar[.gz|.bz2|.xz]}}`" + `
+`
+
+func TestParseTLDRPage(t *testing.T) {
+	cmd, err := ParseTLDRPage(strings.NewReader(tarPage))
+	if err != nil {
+		t.Fatalf("ParseTLDRPage() error = %v", err)
+	}
+
+	if cmd.Name != "tar" || cmd.Description != "Archiving utility." {
+		t.Errorf("got name %q description %q", cmd.Name, cmd.Description)
+	}
+	if len(cmd.Notes) != 1 {
+		t.Errorf("Notes = %q", cmd.Notes)
+	}
+	if len(cmd.RelatedCommands) != 2 || cmd.RelatedCommands[0] != "7z" {
+		t.Errorf("RelatedCommands = %q", cmd.RelatedCommands)
+	}
+	if len(cmd.References) != 1 || cmd.References[0] != "https://www.gnu.org/software/tar" {
+		t.Errorf("References = %q", cmd.References)
+	}
+	if len(cmd.Examples) != 2 {
+		t.Fatalf("expected 2 examples, got %+v", cmd.Examples)
+	}
+	if cmd.Examples[0].Command != "tar cf <path/to/target.tar> <path/to/file1 path/to/file2 ...>" {
+		t.Errorf("Examples[0].Command = %q", cmd.Examples[0].Command)
+	}
+	if cmd.Examples[0].Description != "[c]reate an archive and write it to a [f]ile" {
+		t.Errorf("Examples[0].Description = %q", cmd.Examples[0].Description)
+	}
+	if cmd.Examples[1].Command != "tar -xvf <path/to/source.tar[.gz|.bz2|.xz]>" {
+		t.Errorf("Examples[1].Command = %q", cmd.Examples[1].Command)
+	}
+	if len(cmd.Usage) != 1 {
+		t.Errorf("Usage = %q", cmd.Usage)
+	}
+}
+
+func TestLoadTLDRPages(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "pages", "common", "tar.md"), tarPage)
+	writeFile(t, filepath.Join(root, "pages", "osx", "tar.md"), "# tar\n\n> BSD tar.\n")
+	writeFile(t, filepath.Join(root, "pages", "linux", "ip.md"), "# ip\n\n> Show network devices.\n")
+
+	commands, err := LoadTLDRPages(root, "")
+	if err != nil {
+		t.Fatalf("LoadTLDRPages() error = %v", err)
+	}
+
+	if len(commands) != 2 {
+		t.Fatalf("expected 2 commands, got %d", len(commands))
+	}
+	if commands[0].Name != "tar" || commands[0].Description != "Archiving utility." {
+		t.Errorf("common page should take precedence, got %+v", commands[0])
+	}
+	if strings.Join(commands[0].Platforms, ",") != "linux,darwin" {
+		t.Errorf("Platforms = %q", commands[0].Platforms)
+	}
+}
+
+func TestMergeCommand(t *testing.T) {
+	existing := &model.Command{
+		Name:        "tar",
+		Category:    "操作系统/通用Linux命令",
+		Description: "打包归档工具",
+		Usage:       []string{"tar [options] <file>"},
+		Examples: []model.Example{
+			{Command: "tar cf <path/to/target.tar>  <path/to/file1 path/to/file2 ...>", Description: "创建归档"},
+		},
+		Platforms: []string{"linux"},
+	}
+	incoming, err := ParseTLDRPage(strings.NewReader(tarPage))
+	if err != nil {
+		t.Fatalf("ParseTLDRPage() error = %v", err)
+	}
+
+	changed, conflicts := MergeCommand(existing, incoming)
+	if !changed {
+		t.Error("expected merge to add new example")
+	}
+	if len(conflicts) != 0 {
+		t.Errorf("a differently worded description is not a conflict: %+v", conflicts)
+	}
+	if existing.Description != "打包归档工具" {
+		t.Errorf("curated description was overwritten: %q", existing.Description)
+	}
+	if len(existing.Examples) != 2 || existing.Examples[0].Description != "创建归档" {
+		t.Errorf("Examples = %+v", existing.Examples)
+	}
+	if len(existing.Platforms) != 1 || len(existing.Usage) != 1 {
+		t.Errorf("curated platforms/usage changed: %q %q", existing.Platforms, existing.Usage)
+	}
+
+	if changed, _ := MergeCommand(existing, incoming); changed {
+		t.Error("merging the same page twice should be a no-op")
+	}
+
+	// common 目录的页面平台为 linux、darwin，同一说明的示例给出了不同命令行
+	incoming.Platforms = []string{"linux", "darwin"}
+	incoming.Examples = append(incoming.Examples, model.Example{Command: "tar czf <target.tar.gz> <dir>", Description: "创建归档"})
+	changed, conflicts = MergeCommand(existing, incoming)
+	if changed {
+		t.Error("conflicting content should not be merged")
+	}
+	if len(conflicts) != 2 || conflicts[0].Field != "platforms" || conflicts[1].Field != "examples" {
+		t.Fatalf("expected platform and example conflicts, got %+v", conflicts)
+	}
+	if conflicts[0].Existing != "linux" || conflicts[0].Incoming != "linux, darwin" {
+		t.Errorf("platform conflict = %+v", conflicts[0])
+	}
+	if conflicts[1].Existing != existing.Examples[0].Command || conflicts[1].Incoming != "tar czf <target.tar.gz> <dir>" {
+		t.Errorf("example conflict = %+v", conflicts[1])
+	}
+	if len(existing.Examples) != 2 || strings.Join(existing.Platforms, ",") != "linux" {
+		t.Errorf("curated examples/platforms changed: %+v %q", existing.Examples, existing.Platforms)
+	}
+
+	incoming.Category = "归档工具"
+	if _, conflicts := MergeCommand(existing, incoming); len(conflicts) != 3 || conflicts[0].Field != "category" {
+		t.Errorf("expected a category conflict, got %+v", conflicts)
+	}
+	if existing.Category != "操作系统/通用Linux命令" {
+		t.Errorf("curated category was overwritten: %q", existing.Category)
+	}
+}
+
+func TestDatasetMerge(t *testing.T) {
+	dataDir := t.TempDir()
+	metadata := `version: "1.0.0"
+
+# 分类
+categories:
+  os_common:
+    id: os_common
+    name: "操作系统/通用Linux命令"
+    order: 1
+
+data_files:
+  - "os/common.yaml"
+
+runbook_files: []
+`
+	writeFile(t, filepath.Join(dataDir, "metadata.yaml"), metadata)
+	writeFile(t, filepath.Join(dataDir, "os", "common.yaml"), `# 通用 Linux 命令
+category: "操作系统/通用Linux命令"
+description: "通用命令"
+
+commands:
+  - name: "tar"
+    category: "操作系统/通用Linux命令"
+    description: "打包归档工具"
+    platforms:
+      - "linux"
+    usage:
+      - "tar [options] <file>"
+    examples:
+      - command: "tar cf a.tar a"
+        description: "创建归档"
+    version_check: "tar --version"
+
+  # 文件
+  - name: "ls"
+    category: "操作系统/通用Linux命令"
+    description: "列出目录内容"
+    platforms:
+      - "linux"
+    usage:
+      - "ls"
+    examples:
+      - command: "ls -l"
+        description: "长格式"
+`)
+
+	dataset, err := LoadDataset(dataDir)
+	if err != nil {
+		t.Fatalf("LoadDataset() error = %v", err)
+	}
+
+	incoming, err := ParseTLDRPage(strings.NewReader(tarPage))
+	if err != nil {
+		t.Fatalf("ParseTLDRPage() error = %v", err)
+	}
+	ip := &model.Command{Name: "ip", Description: "Show network devices.", Usage: []string{"ip a"},
+		Examples: []model.Example{{Command: "ip a", Description: "List addresses"}}, Platforms: []string{"linux"}}
+
+	target := dataset.File("os/tldr.yaml", "操作系统/通用Linux命令", "tldr")
+	result, err := dataset.Merge([]*model.Command{incoming, ip}, func(*model.Command) *DataFile { return target })
+	if err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	if len(result.Updated) != 1 || len(result.Added) != 1 {
+		t.Fatalf("result = %+v", result)
+	}
+
+	saved, err := dataset.Save()
+	if err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if len(saved) != 3 {
+		t.Errorf("saved = %q, expected common.yaml, tldr.yaml and metadata.yaml", saved)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dataDir, "os", "common.yaml"))
+	if err != nil {
+		t.Fatalf("failed to read data file: %v", err)
+	}
+	out := string(content)
+	for _, expected := range []string{
+		`version_check: "tar --version"`,
+		`command: "tar cf <path/to/target.tar> <path/to/file1 path/to/file2 ...>"`,
+		`description: "列出目录内容"`,
+		"# 通用 Linux 命令\ncategory: \"操作系统/通用Linux命令\"\ndescription: \"通用命令\"\n\ncommands:\n  - name: \"tar\"",
+		"\n\n  # 文件\n  - name: \"ls\"",
+	} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("data file does not contain %q:\n%s", expected, out)
+		}
+	}
+	if strings.Contains(out, "install_required") {
+		t.Errorf("zero-valued fields should not be written:\n%s", out)
+	}
+
+	// metadata.yaml 只插入新的数据文件，空行和注释保持原样
+	content, err = os.ReadFile(filepath.Join(dataDir, "metadata.yaml"))
+	if err != nil {
+		t.Fatalf("failed to read metadata: %v", err)
+	}
+	if want := strings.Replace(metadata, "  - \"os/common.yaml\"\n", "  - \"os/common.yaml\"\n  - \"os/tldr.yaml\"\n", 1); string(content) != want {
+		t.Errorf("metadata.yaml = \n%s\nwant\n%s", content, want)
+	}
+
+	// 写回后应能被重新加载
+	reloaded, err := LoadDataset(dataDir)
+	if err != nil {
+		t.Fatalf("LoadDataset() after save error = %v", err)
+	}
+	if cmd, _ := reloaded.Lookup("ip"); cmd == nil || cmd.Category != "操作系统/通用Linux命令" {
+		t.Errorf("new command was not saved: %+v", cmd)
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+}
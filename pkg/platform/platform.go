@@ -0,0 +1,149 @@
+// Package platform 识别当前主机的操作系统和 Linux 发行版，并判断命令是否适用
+package platform
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strings"
+)
+
+// osReleaseFiles os-release 文件的查找顺序
+var osReleaseFiles = []string{"/etc/os-release", "/usr/lib/os-release"}
+
+// aliases 平台名称别名到数据集平台名称的映射
+var aliases = map[string]string{
+	"macos": "darwin",
+	"osx":   "darwin",
+	"mac":   "darwin",
+	"win":   "windows",
+}
+
+// Host 主机平台
+type Host struct {
+	OS      string   // 操作系统，与 runtime.GOOS 一致，如 linux、darwin、windows
+	Distro  string   // Linux 发行版 ID，如 ubuntu、centos，未知时为空
+	Like    []string // 兼容的上游发行版（os-release 的 ID_LIKE），如 debian、rhel
+	Version string   // 发行版版本号
+}
+
+// Detect 识别当前主机平台；Linux 下读取 os-release 获取发行版
+func Detect() Host {
+	host := Host{OS: runtime.GOOS}
+	if host.OS != "linux" {
+		return host
+	}
+
+	for _, path := range osReleaseFiles {
+		f, err := os.Open(path)
+		if err != nil {
+			continue
+		}
+		host.Distro, host.Like, host.Version = ParseOSRelease(f)
+		f.Close()
+		break
+	}
+	return host
+}
+
+// Parse 解析 "os" 或 "os/distro" 形式的平台说明，如 macos、linux/centos
+func Parse(spec string) (Host, error) {
+	osName, distro, _ := strings.Cut(strings.TrimSpace(spec), "/")
+	host := Host{OS: Normalize(osName), Distro: strings.ToLower(strings.TrimSpace(distro))}
+
+	switch host.OS {
+	case "linux", "darwin", "windows":
+	default:
+		return Host{}, fmt.Errorf("unknown platform: %s", spec)
+	}
+	if host.Distro != "" && host.OS != "linux" {
+		return Host{}, fmt.Errorf("distro is only supported for linux: %s", spec)
+	}
+	return host, nil
+}
+
+// ParseOSRelease 从 os-release 内容中读取发行版 ID、ID_LIKE 和 VERSION_ID
+func ParseOSRelease(r io.Reader) (id string, like []string, version string) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(strings.TrimSpace(scanner.Text()), "=")
+		if !ok || strings.HasPrefix(key, "#") {
+			continue
+		}
+		value = strings.Trim(value, `"'`)
+
+		switch key {
+		case "ID":
+			id = strings.ToLower(value)
+		case "ID_LIKE":
+			like = strings.Fields(strings.ToLower(value))
+		case "VERSION_ID":
+			version = value
+		}
+	}
+	return id, like, version
+}
+
+// Normalize 统一平台名称大小写和别名，如 macOS -> darwin
+func Normalize(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if alias, ok := aliases[name]; ok {
+		return alias
+	}
+	return name
+}
+
+// Matches 检查命令的平台和发行版限定是否适用于该主机
+//
+// unix 适用于除 Windows 以外的系统；发行版限定只在 Linux 且已知发行版时生效。
+func (h Host) Matches(platforms, distros []string) bool {
+	if !h.matchesOS(platforms) {
+		return false
+	}
+	if len(distros) == 0 || h.OS != "linux" || h.Distro == "" {
+		return true
+	}
+
+	for _, d := range distros {
+		d = strings.ToLower(d)
+		if d == h.Distro {
+			return true
+		}
+		for _, like := range h.Like {
+			if d == like {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (h Host) matchesOS(platforms []string) bool {
+	return Covers(platforms, h.OS)
+}
+
+// Covers 检查平台列表是否包含指定操作系统，unix 包含除 Windows 以外的系统
+func Covers(platforms []string, osName string) bool {
+	osName = Normalize(osName)
+	for _, p := range platforms {
+		p = Normalize(p)
+		if p == osName || (p == "unix" && osName != "windows") {
+			return true
+		}
+	}
+	return false
+}
+
+// String 返回便于显示的平台说明，如 linux/ubuntu 22.04
+func (h Host) String() string {
+	if h.Distro == "" {
+		return h.OS
+	}
+	s := h.OS + "/" + h.Distro
+	if h.Version != "" {
+		s += " " + h.Version
+	}
+	return s
+}
@@ -0,0 +1,94 @@
+package platform
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseOSRelease(t *testing.T) {
+	content := `NAME="Rocky Linux"
+# comment
+ID="rocky"
+ID_LIKE="rhel centos fedora"
+VERSION_ID="9.3"
+`
+	id, like, version := ParseOSRelease(strings.NewReader(content))
+	if id != "rocky" || version != "9.3" {
+		t.Errorf("Unexpected id/version: %q %q", id, version)
+	}
+	if len(like) != 3 || like[0] != "rhel" {
+		t.Errorf("Unexpected ID_LIKE: %v", like)
+	}
+}
+
+func TestParse(t *testing.T) {
+	host, err := Parse("Linux/CentOS")
+	if err != nil || host.OS != "linux" || host.Distro != "centos" {
+		t.Errorf("Parse(linux/centos) = %+v, %v", host, err)
+	}
+
+	host, err = Parse("macos")
+	if err != nil || host.OS != "darwin" {
+		t.Errorf("Parse(macos) = %+v, %v", host, err)
+	}
+
+	if _, err := Parse("plan9"); err == nil {
+		t.Error("Expected error for unknown platform")
+	}
+	if _, err := Parse("darwin/ubuntu"); err == nil {
+		t.Error("Expected error for distro on non-linux platform")
+	}
+}
+
+func TestHostMatches(t *testing.T) {
+	ubuntu := Host{OS: "linux", Distro: "ubuntu", Like: []string{"debian"}}
+	rocky := Host{OS: "linux", Distro: "rocky", Like: []string{"rhel", "centos", "fedora"}}
+	unknown := Host{OS: "linux"}
+	mac := Host{OS: "darwin"}
+	windows := Host{OS: "windows"}
+
+	tests := []struct {
+		name      string
+		host      Host
+		platforms []string
+		distros   []string
+		want      bool
+	}{
+		{"os match", ubuntu, []string{"linux"}, nil, true},
+		{"os mismatch", windows, []string{"linux", "darwin"}, nil, false},
+		{"macos alias", mac, []string{"macos"}, nil, true},
+		{"unix on darwin", mac, []string{"unix"}, nil, true},
+		{"unix not windows", windows, []string{"unix"}, nil, false},
+		{"distro match", ubuntu, []string{"linux"}, []string{"ubuntu", "debian"}, true},
+		{"distro like match", rocky, []string{"linux"}, []string{"centos", "rhel"}, true},
+		{"distro mismatch", ubuntu, []string{"linux"}, []string{"centos", "rhel"}, false},
+		{"unknown distro", unknown, []string{"linux"}, []string{"centos"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.host.Matches(tt.platforms, tt.distros); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCovers(t *testing.T) {
+	if !Covers([]string{"linux", "macos"}, "darwin") || !Covers([]string{"unix"}, "linux") {
+		t.Error("Expected platforms to cover os")
+	}
+	if Covers([]string{"unix"}, "windows") || Covers([]string{"linux"}, "macos") {
+		t.Error("Unexpected coverage")
+	}
+}
+
+func TestHostString(t *testing.T) {
+	host := Host{OS: "linux", Distro: "debian", Version: "12"}
+	if got := host.String(); got != "linux/debian 12" {
+		t.Errorf("String() = %q", got)
+	}
+	if got := (Host{OS: "darwin"}).String(); got != "darwin" {
+		t.Errorf("String() = %q", got)
+	}
+}
@@ -0,0 +1,18 @@
+#!/bin/bash
+# Generate the command reference site for GitHub Pages
+# Output goes to docs/commands, linked from docs/index.html
+
+set -e
+
+SITE_DIR="docs/commands"
+DATA_DIR="data"
+
+echo "Generating command reference site..."
+rm -rf "$SITE_DIR"
+go run ./cmd/cli export \
+    --all-platforms \
+    --format html \
+    --dir "$SITE_DIR" \
+    --data-dir "$DATA_DIR"
+
+echo "Site generated in $SITE_DIR"